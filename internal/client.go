@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/jsonapi"
@@ -16,12 +19,18 @@ import (
 
 const BASE_URL = "https://api.imgix.com/api/v1/"
 
+// Number of items requested per page when listing
+const listPageSize = 100
+
 const (
 	ImgixResourceSource string = "sources"
 	ImgixResourcePurge  string = "purges"
 	ImgixResourceReport string = "reports"
 )
 
+// Unlike the other resources, purges are created through a singular endpoint
+const imgixPurgeEndpoint = "purge"
+
 type ImgixSource struct {
 	ID               string                `jsonapi:"primary,sources,omitempty" json:"id,omitempty"`
 	Name             string                `jsonapi:"attr,name,omitempty" json:"name,omitempty"`
@@ -30,6 +39,9 @@ type ImgixSource struct {
 	DeploymentStatus string                `jsonapi:"attr,deployment_status,omitempty" json:"deployment_status,omitempty"`
 	SecureURLToken   string                `jsonapi:"attr,secure_url_token,omitempty" json:"secure_url_token,omitempty"`
 	DateDeployed     int                   `jsonapi:"attr,date_deployed,omitempty" json:"date_deployed,omitempty"`
+
+	// RawJSON holds the document as returned by the API when fetched by ID
+	RawJSON []byte `json:"-"`
 }
 
 type ImgixSourceDeployment struct {
@@ -54,11 +66,100 @@ type ImgixSourceDeployment struct {
 	S3SecretKey string  `jsonapi:"attr,s3_secret_key" json:"s3_secret_key,omitempty"`
 	S3Bucket    string  `jsonapi:"attr,s3_bucket" json:"s3_bucket,omitempty"`
 	S3Prefix    *string `jsonapi:"attr,s3_prefix" json:"s3_prefix,omitempty"`
+
+	// Extra holds fields that aren't modeled above, they are merged into the payload as-is
+	Extra map[string]interface{} `json:"-"`
+	// Raw holds every field of the deployment as returned by the API
+	Raw map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON merges the Extra fields into the deployment payload.
+func (d ImgixSourceDeployment) MarshalJSON() ([]byte, error) {
+	// Use an alias so we don't recurse into this method
+	type deployment ImgixSourceDeployment
+	b, err := json.Marshal(deployment(d))
+	if err != nil || len(d.Extra) == 0 {
+		return b, err
+	}
+	merged := make(map[string]interface{})
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range d.Extra {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
+
+// rawSourceDeployment returns the deployment of a source document as returned by the API.
+func rawSourceDeployment(body []byte) map[string]interface{} {
+	var document struct {
+		Data struct {
+			Attributes struct {
+				Deployment map[string]interface{} `json:"deployment"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil
+	}
+	return document.Data.Attributes.Deployment
+}
+
+// rawSourceDeployments returns the deployments of a list of sources as returned by the
+// API, by source ID.
+func rawSourceDeployments(body []byte) map[string]map[string]interface{} {
+	var document struct {
+		Data []struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				Deployment map[string]interface{} `json:"deployment"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil
+	}
+	deployments := make(map[string]map[string]interface{}, len(document.Data))
+	for _, source := range document.Data {
+		deployments[source.ID] = source.Attributes.Deployment
+	}
+	return deployments
+}
+
+type ImgixPurge struct {
+	ID       string `jsonapi:"primary,purges,omitempty" json:"id,omitempty"`
+	URL      string `jsonapi:"attr,url,omitempty" json:"url,omitempty"`
+	SourceID string `jsonapi:"attr,source_id,omitempty" json:"source_id,omitempty"`
+	SubImage bool   `jsonapi:"attr,sub_image" json:"sub_image"`
+}
+
+type ImgixReport struct {
+	ID          string `jsonapi:"primary,reports,omitempty" json:"id,omitempty"`
+	ReportType  string `jsonapi:"attr,report_type,omitempty" json:"report_type,omitempty"`
+	PeriodStart int64  `jsonapi:"attr,period_start,omitempty" json:"period_start,omitempty"`
+	PeriodEnd   int64  `jsonapi:"attr,period_end,omitempty" json:"period_end,omitempty"`
+	Completed   bool   `jsonapi:"attr,completed,omitempty" json:"completed,omitempty"`
+	ReportKey   string `jsonapi:"attr,report_key,omitempty" json:"report_key,omitempty"`
+	URL         string `jsonapi:"attr,url,omitempty" json:"url,omitempty"`
+}
+
+// ReportNotFoundError is returned when a report doesn't exist, e.g. because it expired.
+type ReportNotFoundError struct {
+	ID string
+}
+
+func (e *ReportNotFoundError) Error() string {
+	return fmt.Sprintf("no report was found with ID %s", e.ID)
 }
 
 type ImgixClient struct {
 	client       http.Client
 	upsertByName bool
+	// downloadClient fetches pre-signed URLs, which must not receive our token
+	downloadClient http.Client
+	// assetLocks serializes the read-modify-write updates of each asset
+	assetLocks sync.Map
 }
 
 func NewImgixClient(authToken string, upsertByName bool) *ImgixClient {
@@ -70,7 +171,11 @@ func NewImgixClient(authToken string, upsertByName bool) *ImgixClient {
 			token:        authToken,
 		},
 	}
-	return &ImgixClient{client: *client, upsertByName: upsertByName}
+	return &ImgixClient{
+		client:         *client,
+		upsertByName:   upsertByName,
+		downloadClient: http.Client{Timeout: time.Minute * 5},
+	}
 }
 
 type AuthenticatedRateLimitedTransport struct {
@@ -116,39 +221,162 @@ func (c *ImgixClient) GetSourceByID(ctx context.Context, resourceId string) (*Im
 			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
 		}
 	}
+	source.Deployment.Raw = rawSourceDeployment(reqBody)
+	source.RawJSON = reqBody
 	return source, nil
 }
 
+// AmbiguousSourcesError is returned when a lookup matches more than one source.
+type AmbiguousSourcesError struct {
+	Lookup  string
+	Sources []*ImgixSource
+}
+
+func (e *AmbiguousSourcesError) Error() string {
+	return fmt.Sprintf("more than one source was found with %s", e.Lookup)
+}
+
 func (c *ImgixClient) GetSourceByName(ctx context.Context, sourceName string) (*ImgixSource, error) {
 	if sourceName == "" {
 		return nil, fmt.Errorf("missing sourceName, can't call GetSourceByName")
 	}
-	resp, err := c.client.Get(BASE_URL + ImgixResourceSource + "?filter[name]=" + sourceName)
+	sources, err := c.ListSources(ctx, map[string]string{"name": sourceName})
 	if err != nil {
 		return nil, err
 	}
-	if resp.Body != nil {
-		defer resp.Body.Close()
+	if len(sources) == 0 {
+		return nil, nil
+	} else if len(sources) == 1 {
+		return sources[0], nil
 	}
-	reqBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read body: %w", err)
+	return nil, &AmbiguousSourcesError{Lookup: "name: " + sourceName, Sources: sources}
+}
+
+func (c *ImgixClient) GetSourceBySubdomain(ctx context.Context, subdomain string) (*ImgixSource, error) {
+	if subdomain == "" {
+		return nil, fmt.Errorf("missing subdomain, can't call GetSourceBySubdomain")
 	}
-	resp.Body = io.NopCloser(bytes.NewReader(reqBody))
-	sources, err := jsonapi.UnmarshalManyPayload(resp.Body, reflect.TypeOf(new(ImgixSource)))
+	// The API can't filter on subdomains, so look through every source
+	sources, err := c.ListSources(ctx, nil)
 	if err != nil {
-		if resp.StatusCode == 200 {
-			return nil, fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
-		} else {
-			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+		return nil, err
+	}
+	var matches []*ImgixSource
+	for _, source := range sources {
+		for _, s := range source.Deployment.ImgixSubdomains {
+			if s == subdomain {
+				matches = append(matches, source)
+				break
+			}
 		}
 	}
-	if len(sources) == 0 {
+	if len(matches) == 0 {
 		return nil, nil
-	} else if len(sources) == 1 {
-		return sources[0].(*ImgixSource), nil
+	} else if len(matches) == 1 {
+		return matches[0], nil
+	}
+	return nil, &AmbiguousSourcesError{Lookup: "imgix subdomain: " + subdomain, Sources: matches}
+}
+
+// ListSources returns every source matching the filters, e.g. {"name": "profile"}, following the pagination.
+func (c *ImgixClient) ListSources(ctx context.Context, filters map[string]string) ([]*ImgixSource, error) {
+	var sources []*ImgixSource
+	err := c.WalkSources(ctx, filters, func(source *ImgixSource) bool {
+		sources = append(sources, source)
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("more than one source was found with name: %s; can't import", sourceName)
+	return sources, nil
+}
+
+// WalkSources calls fn with every source matching the filters, one page at a time, until
+// fn returns false or there are no more sources.
+func (c *ImgixClient) WalkSources(ctx context.Context, filters map[string]string, fn func(*ImgixSource) bool) error {
+	query := url.Values{}
+	for key, value := range filters {
+		query.Set("filter["+key+"]", value)
+	}
+	query.Set("page[size]", strconv.Itoa(listPageSize))
+	pageURL := BASE_URL + ImgixResourceSource + "?" + query.Encode()
+	for pageURL != "" {
+		resp, err := c.client.Get(pageURL)
+		if err != nil {
+			return err
+		}
+		reqBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read body: %w", err)
+		}
+		items, err := jsonapi.UnmarshalManyPayload(bytes.NewReader(reqBody), reflect.TypeOf(new(ImgixSource)))
+		if err != nil {
+			if resp.StatusCode == 200 {
+				return fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
+			} else {
+				return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+			}
+		}
+		raw := rawSourceDeployments(reqBody)
+		for _, item := range items {
+			source := item.(*ImgixSource)
+			source.Deployment.Raw = raw[source.ID]
+			if !fn(source) {
+				return nil
+			}
+		}
+		// An empty page can't lead anywhere, even if the links say otherwise
+		if len(items) == 0 {
+			return nil
+		}
+		pageURL, err = nextPageURL(pageURL, reqBody)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextPageURL returns the URL of the page after the one in body, or "" if it was the last page.
+// It follows `links.next` and falls back on the page numbers of `meta.pagination`.
+func nextPageURL(pageURL string, body []byte) (string, error) {
+	var document struct {
+		Links struct {
+			Next *string `json:"next"`
+		} `json:"links"`
+		Meta struct {
+			Pagination *struct {
+				CurrentPage int `json:"currentPage"`
+				LastPage    int `json:"lastPage"`
+			} `json:"pagination"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return "", fmt.Errorf("failed to unmarshal pagination: %w", err)
+	}
+	current, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	if document.Links.Next != nil {
+		if *document.Links.Next == "" {
+			return "", nil
+		}
+		next, err := current.Parse(*document.Links.Next)
+		if err != nil {
+			return "", fmt.Errorf("invalid next page link %q: %w", *document.Links.Next, err)
+		}
+		return next.String(), nil
+	}
+	pagination := document.Meta.Pagination
+	if pagination == nil || pagination.CurrentPage >= pagination.LastPage {
+		return "", nil
+	}
+	query := current.Query()
+	query.Set("page[number]", strconv.Itoa(pagination.CurrentPage+1))
+	current.RawQuery = query.Encode()
+	return current.String(), nil
 }
 
 func (c *ImgixClient) CreateSource(ctx context.Context, source *ImgixSource) (*ImgixSource, error) {
@@ -181,6 +409,7 @@ func (c *ImgixClient) CreateSource(ctx context.Context, source *ImgixSource) (*I
 			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
 		}
 	}
+	remoteSource.Deployment.Raw = rawSourceDeployment(reqBody)
 	return remoteSource, nil
 }
 
@@ -222,6 +451,7 @@ func (c *ImgixClient) UpdateSource(ctx context.Context, source *ImgixSource) (*I
 			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
 		}
 	}
+	remoteSource.Deployment.Raw = rawSourceDeployment(reqBody)
 	return remoteSource, nil
 }
 
@@ -260,3 +490,123 @@ func (c *ImgixClient) DeleteSourceByID(ctx context.Context, resourceId string) e
 	}
 	return nil
 }
+
+func (c *ImgixClient) CreatePurge(ctx context.Context, purge *ImgixPurge) (*ImgixPurge, error) {
+	if purge.URL == "" {
+		return nil, fmt.Errorf("missing URL, can't call CreatePurge")
+	}
+	payload, err := jsonapi.Marshal(purge)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader := bytes.NewReader(b)
+	resp, err := c.client.Post(BASE_URL+imgixPurgeEndpoint, jsonapi.MediaType, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	remotePurge := new(ImgixPurge)
+	reqBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(reqBody))
+	if err := jsonapi.UnmarshalPayload(resp.Body, remotePurge); err != nil {
+		if resp.StatusCode == 200 {
+			return nil, fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
+		} else {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+		}
+	}
+	return remotePurge, nil
+}
+
+func (c *ImgixClient) CreateReport(ctx context.Context, report *ImgixReport) (*ImgixReport, error) {
+	payload, err := jsonapi.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader := bytes.NewReader(b)
+	resp, err := c.client.Post(BASE_URL+ImgixResourceReport, jsonapi.MediaType, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	remoteReport := new(ImgixReport)
+	reqBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(reqBody))
+	if err := jsonapi.UnmarshalPayload(resp.Body, remoteReport); err != nil {
+		if resp.StatusCode == 200 {
+			return nil, fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
+		} else {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+		}
+	}
+	return remoteReport, nil
+}
+
+func (c *ImgixClient) GetReportByID(ctx context.Context, reportId string) (*ImgixReport, error) {
+	if reportId == "" {
+		return nil, fmt.Errorf("missing reportId, can't call GetReportByID")
+	}
+	resp, err := c.client.Get(BASE_URL + ImgixResourceReport + "/" + reportId)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &ReportNotFoundError{ID: reportId}
+	}
+	report := new(ImgixReport)
+	reqBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(reqBody))
+	if err := jsonapi.UnmarshalPayload(resp.Body, report); err != nil {
+		if resp.StatusCode == 200 {
+			return nil, fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
+		} else {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+		}
+	}
+	return report, nil
+}
+
+// DownloadReport streams the contents of a completed report, the caller must close the body.
+func (c *ImgixClient) DownloadReport(ctx context.Context, reportURL string) (io.ReadCloser, error) {
+	if reportURL == "" {
+		return nil, fmt.Errorf("missing reportURL, can't call DownloadReport")
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", reportURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.downloadClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		defer resp.Body.Close()
+		reqBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/jsonapi"
+)
+
+const ImgixResourceAsset string = "assets"
+
+// Uploads go through the sources endpoint rather than the assets one
+const imgixUploadEndpoint = "sources/upload"
+
+// Uploads can take a lot longer than the other calls
+const uploadTimeout = 10 * time.Minute
+
+type ImgixAsset struct {
+	ID           string                 `jsonapi:"primary,assets,omitempty" json:"id,omitempty"`
+	SourceID     string                 `jsonapi:"attr,source_id,omitempty" json:"source_id,omitempty"`
+	OriginPath   string                 `jsonapi:"attr,origin_path,omitempty" json:"origin_path,omitempty"`
+	Name         *string                `jsonapi:"attr,name,omitempty" json:"name,omitempty"`
+	Description  *string                `jsonapi:"attr,description,omitempty" json:"description,omitempty"`
+	ContentType  string                 `jsonapi:"attr,content_type,omitempty" json:"content_type,omitempty"`
+	FileSize     int64                  `jsonapi:"attr,file_size,omitempty" json:"file_size,omitempty"`
+	MediaWidth   int64                  `jsonapi:"attr,media_width,omitempty" json:"media_width,omitempty"`
+	MediaHeight  int64                  `jsonapi:"attr,media_height,omitempty" json:"media_height,omitempty"`
+	MediaKind    string                 `jsonapi:"attr,media_kind,omitempty" json:"media_kind,omitempty"`
+	Tags         map[string]interface{} `jsonapi:"attr,tags,omitempty" json:"tags,omitempty"`
+	Categories   []string               `jsonapi:"attr,categories,omitempty" json:"categories,omitempty"`
+	CustomFields map[string]interface{} `jsonapi:"attr,custom_fields,omitempty" json:"custom_fields,omitempty"`
+	DateCreated  int64                  `jsonapi:"attr,date_created,omitempty" json:"date_created,omitempty"`
+	DateModified int64                  `jsonapi:"attr,date_modified,omitempty" json:"date_modified,omitempty"`
+}
+
+// AssetNotFoundError is returned when a source has no asset at the origin path.
+type AssetNotFoundError struct {
+	SourceID   string
+	OriginPath string
+}
+
+func (e *AssetNotFoundError) Error() string {
+	return fmt.Sprintf("no asset was found at %s in source %s", e.OriginPath, e.SourceID)
+}
+
+// assetURL returns the Asset Manager URL of an asset. The origin path is a single path
+// segment, so its slashes are escaped too.
+func assetURL(sourceID, originPath string) string {
+	if !strings.HasPrefix(originPath, "/") {
+		originPath = "/" + originPath
+	}
+	return BASE_URL + ImgixResourceAsset + "/" + url.PathEscape(sourceID) + "/" + url.PathEscape(originPath)
+}
+
+// lockAsset waits for the other updates of the asset to be done, the returned func
+// must be called once this one is.
+func (c *ImgixClient) lockAsset(sourceID, originPath string) func() {
+	lock, _ := c.assetLocks.LoadOrStore(sourceID+"/"+originPath, new(sync.Mutex))
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+func (c *ImgixClient) GetAsset(ctx context.Context, sourceID, originPath string) (*ImgixAsset, error) {
+	if sourceID == "" || originPath == "" {
+		return nil, fmt.Errorf("missing sourceID or originPath, can't call GetAsset")
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", assetURL(sourceID, originPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &AssetNotFoundError{SourceID: sourceID, OriginPath: originPath}
+	}
+	asset := new(ImgixAsset)
+	reqBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	if err := jsonapi.UnmarshalPayload(bytes.NewReader(reqBody), asset); err != nil {
+		if resp.StatusCode == 200 {
+			return nil, fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
+		} else {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+		}
+	}
+	return asset, nil
+}
+
+// UpdateAsset patches the given attributes of an asset, leaving every other attribute as-is.
+// Attributes are passed as a map so they can be cleared with a nil value.
+func (c *ImgixClient) UpdateAsset(ctx context.Context, asset *ImgixAsset, attributes map[string]interface{}) (*ImgixAsset, error) {
+	if asset.ID == "" {
+		return nil, fmt.Errorf("missing ID, can't call UpdateAsset")
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":         asset.ID,
+			"type":       ImgixResourceAsset,
+			"attributes": attributes,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "PATCH", assetURL(asset.SourceID, asset.OriginPath), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", jsonapi.MediaType)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	remoteAsset := new(ImgixAsset)
+	reqBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	if err := jsonapi.UnmarshalPayload(bytes.NewReader(reqBody), remoteAsset); err != nil {
+		if resp.StatusCode == 200 {
+			return nil, fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
+		} else {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+		}
+	}
+	return remoteAsset, nil
+}
+
+// UploadAsset uploads the body to the origin path of a source that allows uploads.
+func (c *ImgixClient) UploadAsset(ctx context.Context, sourceID, originPath, contentType string, body io.Reader, size int64) error {
+	if sourceID == "" || originPath == "" {
+		return fmt.Errorf("missing sourceID or originPath, can't call UploadAsset")
+	}
+	originPath = strings.TrimPrefix(originPath, "/")
+	req, err := http.NewRequestWithContext(ctx, "POST", BASE_URL+imgixUploadEndpoint+"/"+url.PathEscape(sourceID)+"/"+percentEncode(originPath, uriPathSafe), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Add("Content-Type", contentType)
+
+	// Share the authenticated transport but not the timeout
+	uploadClient := c.client
+	uploadClient.Timeout = uploadTimeout
+	resp, err := uploadClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		reqBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+	}
+	return nil
+}
+
+// RefreshAsset makes the Asset Manager read the asset from the origin again.
+func (c *ImgixClient) RefreshAsset(ctx context.Context, sourceID, originPath string) error {
+	if sourceID == "" || originPath == "" {
+		return fmt.Errorf("missing sourceID or originPath, can't call RefreshAsset")
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", assetURL(sourceID, originPath)+"/refresh", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return &AssetNotFoundError{SourceID: sourceID, OriginPath: originPath}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		reqBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+	}
+	return nil
+}
+
+// ListAssets calls fn with every asset of the source matching the query until fn returns
+// false. Assets are decoded one at a time so large sources don't have to fit in memory.
+func (c *ImgixClient) ListAssets(ctx context.Context, sourceID string, query url.Values, fn func(*ImgixAsset) bool) error {
+	if sourceID == "" {
+		return fmt.Errorf("missing sourceID, can't call ListAssets")
+	}
+	cursor := ""
+	for {
+		pageQuery := url.Values{}
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+		pageQuery.Set("page[limit]", strconv.Itoa(listPageSize))
+		if cursor != "" {
+			pageQuery.Set("page[cursor]", cursor)
+		}
+		req, err := http.NewRequestWithContext(ctx, "GET", BASE_URL+ImgixResourceAsset+"/"+url.PathEscape(sourceID)+"?"+pageQuery.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != 200 {
+			reqBody, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(reqBody))
+		}
+		next, count, stopped, err := decodeAssetPage(resp.Body, fn)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal jsonapi data: %w", err)
+		}
+		// An empty page or a missing cursor means we've reached the end
+		if stopped || count == 0 || next == "" || next == cursor {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// decodeAssetPage streams a page of assets into fn, returning the cursor of the next page.
+func decodeAssetPage(body io.Reader, fn func(*ImgixAsset) bool) (next string, count int, stopped bool, err error) {
+	decoder := json.NewDecoder(body)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return "", 0, false, err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", count, false, err
+		}
+		switch token {
+		case "data":
+			if err := expectDelim(decoder, '['); err != nil {
+				return "", count, false, err
+			}
+			for decoder.More() {
+				var node json.RawMessage
+				if err := decoder.Decode(&node); err != nil {
+					return "", count, false, err
+				}
+				// Decode each node as its own document to reuse the jsonapi tags
+				asset := new(ImgixAsset)
+				document := append(append([]byte(`{"data":`), node...), '}')
+				if err := jsonapi.UnmarshalPayload(bytes.NewReader(document), asset); err != nil {
+					return "", count, false, err
+				}
+				count++
+				if !fn(asset) {
+					return "", count, true, nil
+				}
+			}
+			if err := expectDelim(decoder, ']'); err != nil {
+				return "", count, false, err
+			}
+		case "meta":
+			var meta struct {
+				Cursor struct {
+					Next    string `json:"next"`
+					HasMore *bool  `json:"hasMore"`
+				} `json:"cursor"`
+			}
+			if err := decoder.Decode(&meta); err != nil {
+				return "", count, false, err
+			}
+			if meta.Cursor.HasMore == nil || *meta.Cursor.HasMore {
+				next = meta.Cursor.Next
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return "", count, false, err
+			}
+		}
+	}
+	return next, count, false, nil
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// newTestClient returns a client whose requests, to the API or anywhere else, are served
+// by the handler.
+func newTestClient(t *testing.T, handler http.Handler) *ImgixClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r = r.Clone(r.Context())
+		r.URL.Scheme = target.Scheme
+		r.URL.Host = target.Host
+		r.Host = ""
+		return server.Client().Transport.RoundTrip(r)
+	})
+	return &ImgixClient{
+		client:         http.Client{Transport: transport},
+		downloadClient: http.Client{Transport: transport},
+	}
+}
+
+func TestGetReportByIDNotFound(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"status":"404"}]}`, http.StatusNotFound)
+	}))
+
+	_, err := client.GetReportByID(context.Background(), "missing")
+	var notFound *ReportNotFoundError
+	if !errors.As(err, &notFound) || notFound.ID != "missing" {
+		t.Fatalf("expected a ReportNotFoundError, got %v", err)
+	}
+}
+
+// sourcesPage renders a page of sources named after their IDs, with the given top level members.
+func sourcesPage(ids []string, members string) string {
+	var data []string
+	for _, id := range ids {
+		data = append(data, fmt.Sprintf(`{"type":"sources","id":%q,"attributes":{"name":%q,"deployment":{"type":"s3"}}}`, id, id))
+	}
+	return fmt.Sprintf(`{"data":[%s]%s}`, strings.Join(data, ","), members)
+}
+
+func TestWalkSourcesPagination(t *testing.T) {
+	tests := map[string]struct {
+		pages    map[string]string
+		stopAt   string
+		expected []string
+	}{
+		"next links": {
+			pages: map[string]string{
+				"":  sourcesPage([]string{"a", "b"}, `,"links":{"next":"/api/v1/sources?filter%5Bname%5D=n&page%5Bnumber%5D=1"}`),
+				"1": sourcesPage([]string{"c"}, `,"links":{"next":"https://api.imgix.com/api/v1/sources?filter%5Bname%5D=n&page%5Bnumber%5D=2"}`),
+				"2": sourcesPage([]string{"d"}, `,"links":{"next":null}`),
+			},
+			expected: []string{"a", "b", "c", "d"},
+		},
+		"meta pagination": {
+			pages: map[string]string{
+				"":  sourcesPage([]string{"a"}, `,"meta":{"pagination":{"currentPage":0,"lastPage":1}}`),
+				"1": sourcesPage([]string{"b"}, `,"meta":{"pagination":{"currentPage":1,"lastPage":1}}`),
+			},
+			expected: []string{"a", "b"},
+		},
+		"single page without pagination": {
+			pages: map[string]string{
+				"": sourcesPage([]string{"a"}, ""),
+			},
+			expected: []string{"a"},
+		},
+		"empty page": {
+			pages: map[string]string{
+				"": sourcesPage(nil, `,"links":{"next":"/api/v1/sources?filter%5Bname%5D=n&page%5Bnumber%5D=0"}`),
+			},
+		},
+		"stopped early": {
+			pages: map[string]string{
+				"":  sourcesPage([]string{"a", "b"}, `,"links":{"next":"/api/v1/sources?filter%5Bname%5D=n&page%5Bnumber%5D=1"}`),
+				"1": sourcesPage([]string{"c"}, ""),
+			},
+			stopAt:   "b",
+			expected: []string{"a", "b"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("filter[name]") != "n" {
+					t.Errorf("expected the filters on every page, got %s", r.URL.RawQuery)
+				}
+				page, ok := test.pages[r.URL.Query().Get("page[number]")]
+				if !ok {
+					t.Errorf("unexpected page requested: %s", r.URL.RawQuery)
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/vnd.api+json")
+				_, _ = w.Write([]byte(page))
+			}))
+
+			var ids []string
+			err := client.WalkSources(context.Background(), map[string]string{"name": "n"}, func(source *ImgixSource) bool {
+				ids = append(ids, source.ID)
+				return source.ID != test.stopAt
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ids, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// With the datasource.DataSource implementation
+func NewAssetDataSource() datasource.DataSource {
+	return &AssetDataSource{}
+}
+
+// Ensure the implementation satisfies the datasource.DataSourceWithConfigure interface.
+var _ datasource.DataSourceWithConfigure = &AssetDataSource{}
+
+type AssetDataSource struct {
+	client *ImgixClient
+}
+
+type AssetModel struct {
+	ID           types.String `tfsdk:"id"`
+	SourceID     types.String `tfsdk:"source_id"`
+	OriginPath   types.String `tfsdk:"origin_path"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	ContentType  types.String `tfsdk:"content_type"`
+	FileSize     types.Int64  `tfsdk:"file_size"`
+	MediaWidth   types.Int64  `tfsdk:"media_width"`
+	MediaHeight  types.Int64  `tfsdk:"media_height"`
+	MediaKind    types.String `tfsdk:"media_kind"`
+	Tags         types.List   `tfsdk:"tags"`
+	Categories   types.List   `tfsdk:"categories"`
+	CustomFields types.Map    `tfsdk:"custom_fields"`
+	DateCreated  types.Int64  `tfsdk:"date_created"`
+	DateModified types.Int64  `tfsdk:"date_modified"`
+}
+
+// assetAttributes returns the attributes of an asset, with the source ID and origin path
+// required when they are used to look the asset up.
+func assetAttributes(lookup bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{Computed: true},
+		"source_id": schema.StringAttribute{
+			Required:            lookup,
+			Computed:            !lookup,
+			MarkdownDescription: "ID of the source of the asset.",
+		},
+		"origin_path": schema.StringAttribute{
+			Required:            lookup,
+			Computed:            !lookup,
+			MarkdownDescription: "Path of the asset in the origin, e.g. `/images/hero.png`.",
+		},
+		"name":         schema.StringAttribute{Computed: true},
+		"description":  schema.StringAttribute{Computed: true},
+		"content_type": schema.StringAttribute{Computed: true, MarkdownDescription: "MIME type of the asset, e.g. `image/png`."},
+		"file_size":    schema.Int64Attribute{Computed: true, MarkdownDescription: "Size of the asset in bytes."},
+		"media_width":  schema.Int64Attribute{Computed: true, MarkdownDescription: "Width of the asset in pixels."},
+		"media_height": schema.Int64Attribute{Computed: true, MarkdownDescription: "Height of the asset in pixels."},
+		"media_kind":   schema.StringAttribute{Computed: true, MarkdownDescription: "Kind of the asset, e.g. `IMAGE`."},
+		"tags": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Tags of the asset, sorted.",
+		},
+		"categories": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"custom_fields": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Custom fields of the asset. Values that aren't strings are JSON encoded.",
+		},
+		"date_created":  schema.Int64Attribute{Computed: true, MarkdownDescription: "Unix timestamp of the creation of the asset."},
+		"date_modified": schema.Int64Attribute{Computed: true, MarkdownDescription: "Unix timestamp of the last change to the asset."},
+	}
+}
+
+func (d *AssetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset"
+}
+
+func (d *AssetDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches an asset of a source from the Asset Manager. Fails when the asset doesn't exist.",
+		Attributes:          assetAttributes(true),
+	}
+}
+
+func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	data := new(AssetModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch our remote data
+	asset, err := d.client.GetAsset(ctx, data.SourceID.ValueString(), data.OriginPath.ValueString())
+	var notFound *AssetNotFoundError
+	if errors.As(err, &notFound) {
+		resp.Diagnostics.AddError("Asset Not Found", err.Error())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get asset", err.Error())
+		return
+	}
+
+	// Convert our remote data into our local model
+	resp.Diagnostics.Append(convertAssetToAssetModel(ctx, asset, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func convertAssetToAssetModel(ctx context.Context, asset *ImgixAsset, target *AssetModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Tags map to their confidence, only their names are useful here
+	tags := make([]string, 0, len(asset.Tags))
+	for tag := range asset.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	customFields, err := stringifyValues(asset.CustomFields)
+	if err != nil {
+		diags.AddError("Failed to encode custom_fields", err.Error())
+		return diags
+	}
+
+	var d diag.Diagnostics
+	target.Tags, d = types.ListValueFrom(ctx, types.StringType, tags)
+	diags.Append(d...)
+	categories := asset.Categories
+	if categories == nil {
+		categories = []string{}
+	}
+	target.Categories, d = types.ListValueFrom(ctx, types.StringType, categories)
+	diags.Append(d...)
+	target.CustomFields, d = types.MapValueFrom(ctx, types.StringType, customFields)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	target.ID = types.StringValue(asset.ID)
+	// Keep the values the asset was looked up with
+	if target.SourceID.IsNull() {
+		target.SourceID = types.StringValue(asset.SourceID)
+	}
+	if target.OriginPath.IsNull() {
+		target.OriginPath = types.StringValue(asset.OriginPath)
+	}
+	target.Name = types.StringPointerValue(asset.Name)
+	target.Description = types.StringPointerValue(asset.Description)
+	target.ContentType = types.StringValue(asset.ContentType)
+	target.FileSize = types.Int64Value(asset.FileSize)
+	target.MediaWidth = types.Int64Value(asset.MediaWidth)
+	target.MediaHeight = types.Int64Value(asset.MediaHeight)
+	target.MediaKind = types.StringValue(asset.MediaKind)
+	target.DateCreated = types.Int64Value(asset.DateCreated)
+	target.DateModified = types.Int64Value(asset.DateModified)
+	return diags
+}
@@ -0,0 +1,272 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default cap on the number of assets returned
+const defaultAssetsMaxResults = 1000
+
+// With the datasource.DataSource implementation
+func NewAssetsDataSource() datasource.DataSource {
+	return &AssetsDataSource{}
+}
+
+// Ensure the implementation satisfies the datasource.DataSourceWithConfigure interface.
+var _ datasource.DataSourceWithConfigure = &AssetsDataSource{}
+
+type AssetsDataSource struct {
+	client *ImgixClient
+}
+
+type AssetsModel struct {
+	SourceID         types.String   `tfsdk:"source_id"`
+	OriginPathPrefix types.String   `tfsdk:"origin_path_prefix"`
+	Tags             []types.String `tfsdk:"tags"`
+	Categories       []types.String `tfsdk:"categories"`
+	ContentType      types.String   `tfsdk:"content_type"`
+	CreatedAfter     types.String   `tfsdk:"created_after"`
+	CreatedBefore    types.String   `tfsdk:"created_before"`
+	ModifiedAfter    types.String   `tfsdk:"modified_after"`
+	ModifiedBefore   types.String   `tfsdk:"modified_before"`
+	Sort             types.String   `tfsdk:"sort"`
+	MaxResults       types.Int64    `tfsdk:"max_results"`
+	Truncated        types.Bool     `tfsdk:"truncated"`
+	Assets           []AssetModel   `tfsdk:"assets"`
+}
+
+// assetsDateRange is an inclusive range of unix timestamps, zero when unbounded.
+type assetsDateRange struct {
+	After, Before int64
+}
+
+func (r assetsDateRange) contains(timestamp int64) bool {
+	return (r.After == 0 || timestamp >= r.After) && (r.Before == 0 || timestamp <= r.Before)
+}
+
+func (d *AssetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assets"
+}
+
+func (d *AssetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AssetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dateFilter := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{Optional: true, MarkdownDescription: description + ", in RFC 3339 format."}
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the assets of a source from the Asset Manager, optionally filtered. All filters must match.",
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the source.",
+			},
+			"origin_path_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return assets whose origin path starts with this prefix, e.g. `/images/`.",
+			},
+			"tags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only return assets with all of these tags.",
+			},
+			"categories": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only return assets in all of these categories.",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return assets of this MIME type, e.g. `image/png`.",
+			},
+			"created_after":   dateFilter("Only return assets created at or after this date"),
+			"created_before":  dateFilter("Only return assets created at or before this date"),
+			"modified_after":  dateFilter("Only return assets modified at or after this date"),
+			"modified_before": dateFilter("Only return assets modified at or before this date"),
+			"sort": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Field to sort the assets by, prefixed with `-` for a descending order, e.g. `-date_created`.",
+			},
+			"max_results": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of assets to return. Defaults to `%d`.", defaultAssetsMaxResults),
+			},
+			"truncated": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether more assets matched than `max_results`.",
+			},
+			"assets": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching assets.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: assetAttributes(false),
+				},
+			},
+		},
+	}
+}
+
+func (d *AssetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	data := new(AssetsModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxResults := int64(defaultAssetsMaxResults)
+	if !data.MaxResults.IsNull() {
+		maxResults = data.MaxResults.ValueInt64()
+		if maxResults < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_results"), "Invalid Max Results", "The max_results must be at least 1.")
+			return
+		}
+	}
+	created := assetsDateRange{
+		After:  parseAssetsDate(data.CreatedAfter, "created_after", resp),
+		Before: parseAssetsDate(data.CreatedBefore, "created_before", resp),
+	}
+	modified := assetsDateRange{
+		After:  parseAssetsDate(data.ModifiedAfter, "modified_after", resp),
+		Before: parseAssetsDate(data.ModifiedBefore, "modified_before", resp),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Let the API do the heavy filtering, everything is checked again below
+	query := url.Values{}
+	if !data.OriginPathPrefix.IsNull() {
+		query.Set("filter[origin_path]", data.OriginPathPrefix.ValueString())
+	}
+	if len(data.Tags) > 0 {
+		query.Set("filter[tags]", joinStrings(data.Tags))
+	}
+	if len(data.Categories) > 0 {
+		query.Set("filter[categories]", joinStrings(data.Categories))
+	}
+	if !data.ContentType.IsNull() {
+		query.Set("filter[content_type]", data.ContentType.ValueString())
+	}
+	setAssetsDateFilter(query, "date_created", created)
+	setAssetsDateFilter(query, "date_modified", modified)
+	if !data.Sort.IsNull() {
+		query.Set("sort", data.Sort.ValueString())
+	}
+
+	data.Truncated = types.BoolValue(false)
+	data.Assets = []AssetModel{}
+	err := d.client.ListAssets(ctx, data.SourceID.ValueString(), query, func(asset *ImgixAsset) bool {
+		if !assetMatchesFilters(asset, data, created, modified) {
+			return true
+		}
+		if int64(len(data.Assets)) == maxResults {
+			data.Truncated = types.BoolValue(true)
+			return false
+		}
+
+		// Convert our remote data into our local model
+		state := new(AssetModel)
+		resp.Diagnostics.Append(convertAssetToAssetModel(ctx, asset, state)...)
+		data.Assets = append(data.Assets, *state)
+		return !resp.Diagnostics.HasError()
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list assets", err.Error())
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// parseAssetsDate returns the date as a unix timestamp, zero when it isn't set.
+func parseAssetsDate(value types.String, attribute string, resp *datasource.ReadResponse) int64 {
+	if value.IsNull() {
+		return 0
+	}
+	date, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Date", err.Error())
+		return 0
+	}
+	return date.Unix()
+}
+
+func setAssetsDateFilter(query url.Values, field string, dates assetsDateRange) {
+	if dates.After != 0 {
+		query.Set("filter["+field+"][gte]", strconv.FormatInt(dates.After, 10))
+	}
+	if dates.Before != 0 {
+		query.Set("filter["+field+"][lte]", strconv.FormatInt(dates.Before, 10))
+	}
+}
+
+func joinStrings(values []types.String) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, value.ValueString())
+	}
+	return strings.Join(parts, ",")
+}
+
+// assetMatchesFilters reports whether the asset matches every filter that is set.
+func assetMatchesFilters(asset *ImgixAsset, filters *AssetsModel, created, modified assetsDateRange) bool {
+	if !filters.OriginPathPrefix.IsNull() && !strings.HasPrefix(asset.OriginPath, filters.OriginPathPrefix.ValueString()) {
+		return false
+	}
+	if !filters.ContentType.IsNull() && asset.ContentType != filters.ContentType.ValueString() {
+		return false
+	}
+	for _, tag := range filters.Tags {
+		if _, ok := asset.Tags[tag.ValueString()]; !ok {
+			return false
+		}
+	}
+	for _, category := range filters.Categories {
+		found := false
+		for _, c := range asset.Categories {
+			found = found || c == category.ValueString()
+		}
+		if !found {
+			return false
+		}
+	}
+	return created.contains(asset.DateCreated) && modified.contains(asset.DateModified)
+}
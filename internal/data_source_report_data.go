@@ -0,0 +1,300 @@
+package internal
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// With the datasource.DataSource implementation
+func NewReportDataDataSource() datasource.DataSource {
+	return &ReportDataDataSource{}
+}
+
+// Ensure the implementation satisfies the datasource.DataSourceWithConfigure interface.
+var _ datasource.DataSourceWithConfigure = &ReportDataDataSource{}
+
+// Ensure the implementation satisfies the datasource.DataSourceWithValidateConfig interface.
+var _ datasource.DataSourceWithValidateConfig = &ReportDataDataSource{}
+
+type ReportDataDataSource struct {
+	client *ImgixClient
+}
+
+type ReportDataModel struct {
+	URL               types.String   `tfsdk:"url"`
+	ReportType        types.String   `tfsdk:"report_type"`
+	SourceID          types.String   `tfsdk:"source_id"`
+	Columns           []types.String `tfsdk:"columns"`
+	ImageAnalytics    types.List     `tfsdk:"image_analytics"`
+	OriginImageErrors types.List     `tfsdk:"origin_image_errors"`
+}
+
+// reportColumn is a column of a report type, exposed as an attribute of its rows.
+type reportColumn struct {
+	Name        string
+	Type        attr.Type
+	Description string
+}
+
+// reportDataColumns lists the columns parsed for each report type, by their normalized name.
+var reportDataColumns = map[string][]reportColumn{
+	"image_analytics": {
+		{"source_id", types.StringType, "ID of the source."},
+		{"date", types.StringType, "Day of the row in `YYYY-MM-DD` format."},
+		{"requests", types.Int64Type, "Number of requests served."},
+		{"bandwidth", types.Int64Type, "Bytes served."},
+		{"origin_images", types.Int64Type, "Number of distinct origin images requested."},
+	},
+	"origin_image_errors": {
+		{"source_id", types.StringType, "ID of the source."},
+		{"path", types.StringType, "Path of the origin image."},
+		{"status_code", types.Int64Type, "HTTP status code returned by the origin."},
+		{"error_count", types.Int64Type, "Number of failed requests."},
+		{"last_seen", types.StringType, "Last time the error happened."},
+	},
+}
+
+// reportRowType returns the object type of the rows of the report type.
+func reportRowType(reportType string) types.ObjectType {
+	attributeTypes := map[string]attr.Type{}
+	for _, column := range reportDataColumns[reportType] {
+		attributeTypes[column.Name] = column.Type
+	}
+	return types.ObjectType{AttrTypes: attributeTypes}
+}
+
+// reportTypes returns the supported report types, sorted.
+func reportTypes() []string {
+	names := make([]string, 0, len(reportDataColumns))
+	for name := range reportDataColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *ReportDataDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_data"
+}
+
+func (d *ReportDataDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *ReportDataDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Downloads a completed report and parses its CSV contents. " +
+			"Column names are lowercased with spaces replaced by underscores, the known columns of each report type are parsed into typed attributes.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Download URL of a completed report, usually `imgixyz_report.url`.",
+			},
+			"report_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Type of the report, usually `imgixyz_report.report_type`. Either `image_analytics` or `origin_image_errors`, its rows are returned in the attribute of the same name.",
+			},
+			"source_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return the rows of this source.",
+			},
+			"columns": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Column names of the report, in order.",
+			},
+		},
+	}
+	for _, reportType := range reportTypes() {
+		attributes := map[string]schema.Attribute{}
+		for _, column := range reportDataColumns[reportType] {
+			description := column.Description + " Null if the report doesn't have this column."
+			switch column.Type {
+			case types.Int64Type:
+				attributes[column.Name] = schema.Int64Attribute{Computed: true, MarkdownDescription: description}
+			default:
+				attributes[column.Name] = schema.StringAttribute{Computed: true, MarkdownDescription: description}
+			}
+		}
+		resp.Schema.Attributes[reportType] = schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: fmt.Sprintf("Rows of the report when `report_type` is `%s`, null otherwise.", reportType),
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: attributes,
+			},
+		}
+	}
+}
+
+// ValidateConfig satisfies the datasource.DataSourceWithValidateConfig interface for ReportDataDataSource.
+func (d *ReportDataDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var reportType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("report_type"), &reportType)...)
+	if resp.Diagnostics.HasError() || reportType.IsNull() || reportType.IsUnknown() {
+		return
+	}
+	if _, ok := reportDataColumns[reportType.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("report_type"),
+			"Unsupported Report Type",
+			fmt.Sprintf("The report_type must be one of %s, got %q.", strings.Join(reportTypes(), ", "), reportType.ValueString()),
+		)
+	}
+}
+
+func (d *ReportDataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	data := new(ReportDataModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Download the report
+	body, err := d.client.DownloadReport(ctx, data.URL.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to download report", err.Error())
+		return
+	}
+	defer body.Close()
+
+	// Parse the rows as they are downloaded
+	diags := parseReportCSV(ctx, body, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// parseReportCSV parses the report into the model, only keeping the rows of the requested source.
+func parseReportCSV(ctx context.Context, body io.Reader, data *ReportDataModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	reportType := data.ReportType.ValueString()
+	reportColumns, ok := reportDataColumns[reportType]
+	if !ok {
+		diags.AddAttributeError(path.Root("report_type"), "Unsupported Report Type", fmt.Sprintf("The report_type must be one of %s, got %q.", strings.Join(reportTypes(), ", "), reportType))
+		return diags
+	}
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		diags.AddError("Failed to parse report", "Unable to read the CSV header: "+err.Error())
+		return diags
+	}
+	indexes := map[string]int{}
+	data.Columns = make([]types.String, len(header))
+	for i, name := range header {
+		column := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		data.Columns[i] = types.StringValue(column)
+		if _, ok := indexes[column]; !ok {
+			indexes[column] = i
+		}
+	}
+	sourceIDColumn, ok := indexes["source_id"]
+	if !data.SourceID.IsNull() && !ok {
+		diags.AddError("Failed to filter report", "The report doesn't have a source_id column to filter on.")
+		return diags
+	}
+
+	rowType := reportRowType(reportType)
+	rows := []attr.Value{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			diags.AddError("Failed to parse report", err.Error())
+			return diags
+		}
+		if !data.SourceID.IsNull() && (sourceIDColumn >= len(record) || record[sourceIDColumn] != data.SourceID.ValueString()) {
+			continue
+		}
+
+		values := make(map[string]attr.Value, len(reportColumns))
+		for _, column := range reportColumns {
+			i, ok := indexes[column.Name]
+			value := ""
+			if ok && i < len(record) {
+				value = strings.TrimSpace(record[i])
+			}
+			switch column.Type {
+			case types.Int64Type:
+				if value == "" {
+					values[column.Name] = types.Int64Null()
+					continue
+				}
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					diags.AddError("Failed to parse report", fmt.Sprintf("Line %d: the %s column must be an integer, got %q.", line, column.Name, value))
+					return diags
+				}
+				values[column.Name] = types.Int64Value(n)
+			default:
+				if !ok || i >= len(record) {
+					values[column.Name] = types.StringNull()
+					continue
+				}
+				values[column.Name] = types.StringValue(value)
+			}
+		}
+		row, d := types.ObjectValue(rowType.AttrTypes, values)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		rows = append(rows, row)
+	}
+
+	// Only the attribute of the requested report type has rows
+	data.ImageAnalytics = types.ListNull(reportRowType("image_analytics"))
+	data.OriginImageErrors = types.ListNull(reportRowType("origin_image_errors"))
+	list, d := types.ListValue(rowType, rows)
+	diags.Append(d...)
+	switch reportType {
+	case "image_analytics":
+		data.ImageAnalytics = list
+	case "origin_image_errors":
+		data.OriginImageErrors = list
+	}
+	return diags
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// originImageErrorRow is a row of the origin_image_errors fixture as plain values.
+type originImageErrorRow struct {
+	SourceID   types.String `tfsdk:"source_id"`
+	Path       types.String `tfsdk:"path"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	ErrorCount types.Int64  `tfsdk:"error_count"`
+	LastSeen   types.String `tfsdk:"last_seen"`
+}
+
+func downloadReportFixture(t *testing.T, name string) *ImgixClient {
+	t.Helper()
+	fixture, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reports/"+name {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write(fixture)
+	}))
+}
+
+func TestReportDataFromFixture(t *testing.T) {
+	ctx := context.Background()
+	client := downloadReportFixture(t, "origin_image_errors.csv")
+
+	tests := map[string]struct {
+		sourceID types.String
+		expected []originImageErrorRow
+	}{
+		"all rows": {
+			sourceID: types.StringNull(),
+			expected: []originImageErrorRow{
+				{types.StringValue("abc123"), types.StringValue("/users/1.png"), types.Int64Value(404), types.Int64Value(12), types.StringValue("2026-09-30T10:00:00Z")},
+				{types.StringValue("abc123"), types.StringValue("/docs/a,b.pdf"), types.Int64Value(500), types.Int64Value(3), types.StringValue("2026-09-29T08:30:00Z")},
+				{types.StringValue("def456"), types.StringValue("/logo.svg"), types.Int64Value(403), types.Int64Value(1), types.StringValue("")},
+			},
+		},
+		"filtered by source": {
+			sourceID: types.StringValue("def456"),
+			expected: []originImageErrorRow{
+				{types.StringValue("def456"), types.StringValue("/logo.svg"), types.Int64Value(403), types.Int64Value(1), types.StringValue("")},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := client.DownloadReport(ctx, "https://assets.imgix.net/reports/origin_image_errors.csv")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer body.Close()
+
+			data := &ReportDataModel{ReportType: types.StringValue("origin_image_errors"), SourceID: test.sourceID}
+			if diags := parseReportCSV(ctx, body, data); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			var columns []string
+			for _, column := range data.Columns {
+				columns = append(columns, column.ValueString())
+			}
+			expectedColumns := []string{"source_id", "path", "status_code", "error_count", "last_seen", "region"}
+			if !reflect.DeepEqual(columns, expectedColumns) {
+				t.Errorf("expected columns %v, got %v", expectedColumns, columns)
+			}
+			if !data.ImageAnalytics.IsNull() {
+				t.Errorf("expected no image_analytics rows, got %v", data.ImageAnalytics)
+			}
+			var rows []originImageErrorRow
+			if diags := data.OriginImageErrors.ElementsAs(ctx, &rows, false); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(rows, test.expected) {
+				t.Errorf("expected rows %v, got %v", test.expected, rows)
+			}
+		})
+	}
+}
+
+func TestParseReportCSVMissingColumns(t *testing.T) {
+	ctx := context.Background()
+	data := &ReportDataModel{ReportType: types.StringValue("image_analytics"), SourceID: types.StringNull()}
+	diags := parseReportCSV(ctx, strings.NewReader("Date,Requests\n2026-09-01,42\n"), data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	rows := data.ImageAnalytics.Elements()
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	attributes := rows[0].(types.Object).Attributes()
+	if !attributes["source_id"].IsNull() || !attributes["bandwidth"].IsNull() {
+		t.Errorf("expected the missing columns to be null, got %v", attributes)
+	}
+	if !attributes["requests"].Equal(types.Int64Value(42)) {
+		t.Errorf("expected 42 requests, got %v", attributes["requests"])
+	}
+}
+
+func TestParseReportCSVErrors(t *testing.T) {
+	tests := map[string]struct {
+		reportType string
+		sourceID   types.String
+		csv        string
+		expected   string
+	}{
+		"empty report": {
+			reportType: "image_analytics",
+			sourceID:   types.StringNull(),
+			csv:        "",
+			expected:   "Unable to read the CSV header",
+		},
+		"filter without source column": {
+			reportType: "image_analytics",
+			sourceID:   types.StringValue("abc123"),
+			csv:        "Date,Requests\n2026-09-01,42\n",
+			expected:   "doesn't have a source_id column",
+		},
+		"invalid number": {
+			reportType: "image_analytics",
+			sourceID:   types.StringNull(),
+			csv:        "Date,Requests\n2026-09-01,many\n",
+			expected:   `Line 2: the requests column must be an integer, got "many".`,
+		},
+		"unsupported report type": {
+			reportType: "top_images",
+			sourceID:   types.StringNull(),
+			csv:        "Path\n/a.png\n",
+			expected:   "must be one of image_analytics, origin_image_errors",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := &ReportDataModel{ReportType: types.StringValue(test.reportType), SourceID: test.sourceID}
+			diags := parseReportCSV(context.Background(), strings.NewReader(test.csv), data)
+			if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), test.expected) {
+				t.Errorf("expected an error containing %q, got %v", test.expected, diags)
+			}
+		})
+	}
+}
+
+func TestDownloadReportBadStatus(t *testing.T) {
+	client := downloadReportFixture(t, "origin_image_errors.csv")
+	_, err := client.DownloadReport(context.Background(), "https://assets.imgix.net/reports/expired.csv")
+	if err == nil || !strings.HasPrefix(err.Error(), "HTTP 404: ") {
+		t.Errorf("expected an HTTP 404 error, got %v", err)
+	}
+}
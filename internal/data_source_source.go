@@ -2,6 +2,8 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -24,6 +25,9 @@ func NewSourceDataSource() datasource.DataSource {
 // Ensure the implementation satisfies the datasource.DataSourceWithConfigure interface.
 var _ datasource.DataSourceWithConfigure = &SourceDataSource{}
 
+// Ensure the implementation satisfies the datasource.DataSourceWithValidateConfig interface.
+var _ datasource.DataSourceWithValidateConfig = &SourceDataSource{}
+
 type SourceDataSource struct {
 	client *ImgixClient
 }
@@ -35,6 +39,38 @@ type SourceModel struct {
 	Enabled    types.Bool       `tfsdk:"enabled"`
 }
 
+// SourceDataModel is the Terraform model of the source data source, which can look
+// up a source by any one of its ID, name or Imgix subdomain.
+type SourceDataModel struct {
+	ID               types.String         `tfsdk:"id"`
+	Name             types.String         `tfsdk:"name"`
+	ImgixSubdomain   types.String         `tfsdk:"imgix_subdomain"`
+	Deployment       *DeploymentDataModel `tfsdk:"deployment"`
+	Enabled          types.Bool           `tfsdk:"enabled"`
+	DeploymentStatus types.String         `tfsdk:"deployment_status"`
+	DateDeployed     types.Int64          `tfsdk:"date_deployed"`
+	SecureURLToken   types.String         `tfsdk:"secure_url_token"`
+	RawJSON          types.String         `tfsdk:"raw_json"`
+}
+
+// DeploymentDataModel extends the shared deployment model with every other field
+// returned by the API.
+type DeploymentDataModel struct {
+	DeploymentModel
+	AllowsUpload          types.Bool   `tfsdk:"allows_upload"`
+	CacheTTLBehavior      types.String `tfsdk:"cache_ttl_behavior"`
+	CacheTTLError         types.Int64  `tfsdk:"cache_ttl_error"`
+	CacheTTLValue         types.Int64  `tfsdk:"cache_ttl_value"`
+	CrossdomainXMLEnabled types.Bool   `tfsdk:"crossdomain_xml_enabled"`
+	CustomDomains         types.Set    `tfsdk:"custom_domains"`
+	DefaultParams         types.Map    `tfsdk:"default_params"`
+	ImageError            types.String `tfsdk:"image_error"`
+	ImageErrorAppendQS    types.Bool   `tfsdk:"image_error_append_qs"`
+	ImageMissing          types.String `tfsdk:"image_missing"`
+	ImageMissingAppendQS  types.Bool   `tfsdk:"image_missing_append_qs"`
+	SecureURLEnabled      types.Bool   `tfsdk:"secure_url_enabled"`
+}
+
 type DeploymentModel struct {
 	Annotation      types.String `tfsdk:"annotation"`
 	Type            types.String `tfsdk:"type"`
@@ -42,20 +78,50 @@ type DeploymentModel struct {
 	S3Prefix        types.String `tfsdk:"s3_prefix"`
 	S3AccessKey     types.String `tfsdk:"s3_access_key"`
 	S3SecretKey     types.String `tfsdk:"s3_secret_key"`
-	ImgixSubdomains types.List   `tfsdk:"imgix_subdomains"`
+	ImgixSubdomains types.Set    `tfsdk:"imgix_subdomains"`
 }
 
-func dataDeployObjectType(computed, required bool) schema.Block {
-	return schema.SingleNestedBlock{
-		Attributes: map[string]schema.Attribute{
-			"annotation":       schema.StringAttribute{Required: required, Computed: computed},
-			"type":             schema.StringAttribute{Required: required, Computed: computed},
-			"s3_bucket":        schema.StringAttribute{Optional: required, Computed: computed},
-			"s3_prefix":        schema.StringAttribute{Optional: required, Computed: computed},
-			"s3_access_key":    schema.StringAttribute{Optional: required, Computed: computed, Sensitive: true},
-			"s3_secret_key":    schema.StringAttribute{Optional: required, Computed: computed, Sensitive: true},
-			"imgix_subdomains": schema.ListAttribute{ElementType: types.StringType, Required: required, Computed: computed},
-		},
+func dataDeployAttributes(computed, required bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"annotation":       schema.StringAttribute{Required: required, Computed: computed},
+		"type":             schema.StringAttribute{Required: required, Computed: computed},
+		"s3_bucket":        schema.StringAttribute{Optional: required, Computed: computed},
+		"s3_prefix":        schema.StringAttribute{Optional: required, Computed: computed},
+		"s3_access_key":    schema.StringAttribute{Optional: required, Computed: computed, Sensitive: true},
+		"s3_secret_key":    schema.StringAttribute{Optional: required, Computed: computed, Sensitive: true},
+		"imgix_subdomains": schema.SetAttribute{ElementType: types.StringType, Required: required, Computed: computed},
+	}
+}
+
+func dataDeployObjectType(computed, required bool) schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Computed:   computed,
+		Attributes: dataDeployAttributes(computed, required),
+	}
+}
+
+// dataFullDeployObjectType describes every deployment field returned by the API.
+func dataFullDeployObjectType() schema.Attribute {
+	attributes := dataDeployAttributes(true, false)
+	attributes["allows_upload"] = schema.BoolAttribute{Computed: true}
+	attributes["cache_ttl_behavior"] = schema.StringAttribute{Computed: true}
+	attributes["cache_ttl_error"] = schema.Int64Attribute{Computed: true}
+	attributes["cache_ttl_value"] = schema.Int64Attribute{Computed: true}
+	attributes["crossdomain_xml_enabled"] = schema.BoolAttribute{Computed: true}
+	attributes["custom_domains"] = schema.SetAttribute{ElementType: types.StringType, Computed: true}
+	attributes["default_params"] = schema.MapAttribute{
+		ElementType:         types.StringType,
+		Computed:            true,
+		MarkdownDescription: "Default rendering parameters, values that aren't strings are JSON encoded.",
+	}
+	attributes["image_error"] = schema.StringAttribute{Computed: true}
+	attributes["image_error_append_qs"] = schema.BoolAttribute{Computed: true}
+	attributes["image_missing"] = schema.StringAttribute{Computed: true}
+	attributes["image_missing_append_qs"] = schema.BoolAttribute{Computed: true}
+	attributes["secure_url_enabled"] = schema.BoolAttribute{Computed: true}
+	return schema.SingleNestedAttribute{
+		Computed:   true,
+		Attributes: attributes,
 	}
 }
 
@@ -82,16 +148,60 @@ func (d *SourceDataSource) Configure(ctx context.Context, req datasource.Configu
 func (d *SourceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"id":      schema.StringAttribute{Required: true},
-			"name":    schema.StringAttribute{Computed: true},
-			"enabled": schema.BoolAttribute{Computed: true},
-		},
-		Blocks: map[string]schema.Block{
-			"deployment": dataDeployObjectType(true, false),
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ID of the source to look up. Exactly one of `id`, `name` or `imgix_subdomain` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the source to look up. Exactly one of `id`, `name` or `imgix_subdomain` must be set.",
+			},
+			"imgix_subdomain": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Imgix subdomain served by the source to look up. Exactly one of `id`, `name` or `imgix_subdomain` must be set.",
+			},
+			"enabled":           schema.BoolAttribute{Computed: true},
+			"deployment":        dataFullDeployObjectType(),
+			"deployment_status": schema.StringAttribute{Computed: true},
+			"date_deployed":     schema.Int64Attribute{Computed: true, MarkdownDescription: "Unix timestamp of the last deployment."},
+			"secure_url_token":  schema.StringAttribute{Computed: true, Sensitive: true},
+			"raw_json": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The source document as returned by the Imgix API.",
+			},
 		},
 	}
 }
 
+// ValidateConfig satisfies the datasource.DataSourceWithValidateConfig interface for SourceDataSource.
+func (d *SourceDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	data := new(SourceDataModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values may still turn out to be null
+	set := 0
+	for _, key := range []types.String{data.ID, data.Name, data.ImgixSubdomain} {
+		if key.IsUnknown() {
+			return
+		}
+		if !key.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Source Lookup",
+			"Exactly one of `id`, `name` or `imgix_subdomain` must be set to look up a source.",
+		)
+	}
+}
+
 func (d *SourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Prevent panic if the provider has not been configured.
 	if d.client == nil {
@@ -103,50 +213,144 @@ func (d *SourceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Read Terraform configuration data into the model
-	data := new(SourceModel)
+	data := new(SourceDataModel)
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Read the deployment block
-	deployment := new(DeploymentModel)
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deployment"), deployment)...)
-	if resp.Diagnostics.HasError() {
+	// Fetch our remote data using whichever lookup key is set
+	var source *ImgixSource
+	var err error
+	switch {
+	case !data.Name.IsNull():
+		source, err = d.client.GetSourceByName(ctx, data.Name.ValueString())
+	case !data.ImgixSubdomain.IsNull():
+		source, err = d.client.GetSourceBySubdomain(ctx, data.ImgixSubdomain.ValueString())
+	default:
+		source, err = d.client.GetSourceByID(ctx, data.ID.ValueString())
+	}
+	var ambiguous *AmbiguousSourcesError
+	if errors.As(err, &ambiguous) {
+		candidates := make([]string, 0, len(ambiguous.Sources))
+		for _, s := range ambiguous.Sources {
+			candidates = append(candidates, fmt.Sprintf("  - %s (name: %s, subdomains: %s)", s.ID, s.Name, strings.Join(s.Deployment.ImgixSubdomains, ", ")))
+		}
+		resp.Diagnostics.AddError(
+			"Ambiguous Source Lookup",
+			"More than one source matches the lookup, please look it up by `id` instead. Candidates:\n"+strings.Join(candidates, "\n"),
+		)
 		return
 	}
-	data.Deployment = deployment
-
-	// Fetch our remote data
-	source, err := d.client.GetSourceByID(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to fetch source by ID", err.Error())
+		resp.Diagnostics.AddError("Failed to fetch source", err.Error())
 		return
 	}
+	if source == nil {
+		resp.Diagnostics.AddError("Source Not Found", "No source matches the lookup.")
+		return
+	}
+
+	// Listing doesn't return the full document, so fetch it by ID
+	if source.RawJSON == nil {
+		source, err = d.client.GetSourceByID(ctx, source.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to fetch source by ID", err.Error())
+			return
+		}
+	}
 
 	// Convert our remote data into our local model
-	state := new(SourceModel)
-	diag := convertSourceToSourceModel(ctx, source, data, state)
+	state := &SourceDataModel{ImgixSubdomain: data.ImgixSubdomain}
+	diag := convertSourceToSourceDataModel(ctx, source, state)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Set the state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// stringifyValues keeps strings as-is and JSON encodes values of any other type.
+func stringifyValues(values map[string]interface{}) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		if s, ok := value.(string); ok {
+			result[key] = s
+			continue
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = string(b)
+	}
+	return result, nil
+}
+
+func convertSourceToSourceDataModel(ctx context.Context, source *ImgixSource, targetSourceDataModel *SourceDataModel) diag.Diagnostics {
+	// Start with the fields shared with the resource
+	base := new(SourceModel)
+	diags := convertSourceToSourceModel(ctx, source, new(SourceModel), base)
+	if diags.HasError() {
+		return diags
+	}
+	targetSourceDataModel.ID = base.ID
+	targetSourceDataModel.Name = base.Name
+	targetSourceDataModel.Enabled = base.Enabled
+	targetSourceDataModel.DeploymentStatus = types.StringValue(source.DeploymentStatus)
+	targetSourceDataModel.DateDeployed = types.Int64Value(int64(source.DateDeployed))
+	targetSourceDataModel.SecureURLToken = types.StringValue(source.SecureURLToken)
+	targetSourceDataModel.RawJSON = types.StringValue(string(source.RawJSON))
+
+	defaultParams, err := stringifyValues(source.Deployment.DefaultParams)
+	if err != nil {
+		diags.AddError("Failed to encode default_params", err.Error())
+		return diags
+	}
+	params, d := types.MapValueFrom(ctx, types.StringType, defaultParams)
+	diags.Append(d...)
+	customDomains, d := types.SetValueFrom(ctx, types.StringType, source.Deployment.CustomDomains)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	deployment := source.Deployment
+	targetSourceDataModel.Deployment = &DeploymentDataModel{
+		DeploymentModel:       *base.Deployment,
+		AllowsUpload:          types.BoolValue(deployment.AllowsUpload),
+		CacheTTLBehavior:      types.StringValue(deployment.CacheTTLBehavior),
+		CacheTTLError:         types.Int64Value(int64(deployment.CacheTTLError)),
+		CacheTTLValue:         types.Int64Value(int64(deployment.CacheTTLValue)),
+		CrossdomainXMLEnabled: types.BoolValue(deployment.CrossdomainXMLEnabled),
+		CustomDomains:         customDomains,
+		DefaultParams:         params,
+		ImageError:            types.StringValue(deployment.ImageError),
+		ImageErrorAppendQS:    types.BoolValue(deployment.ImageErrorAppendQS),
+		ImageMissing:          types.StringValue(deployment.ImageMissing),
+		ImageMissingAppendQS:  types.BoolValue(deployment.ImageMissingAppendQS),
+		SecureURLEnabled:      types.BoolValue(deployment.SecureURLEnabled),
+	}
+	return diags
 }
 
 func convertSourceToSourceModel(ctx context.Context, source *ImgixSource, readSourceModel, targetSourceModel *SourceModel) diag.Diagnostics {
 	targetSourceModel.ID = types.StringValue(source.ID)
 	targetSourceModel.Name = types.StringValue(source.Name)
-	targetSourceModel.Enabled = types.BoolValue(*source.Enabled)
+	// The API may leave enabled out, keep what we knew rather than guessing
+	targetSourceModel.Enabled = readSourceModel.Enabled
+	if source.Enabled != nil {
+		targetSourceModel.Enabled = types.BoolValue(*source.Enabled)
+	}
 
 	// Set domains
 	var imgixSubdomains []attr.Value
 	for _, a := range source.Deployment.ImgixSubdomains {
 		imgixSubdomains = append(imgixSubdomains, types.StringValue(a))
 	}
-	subdomains, diag := types.ListValue(types.StringType, imgixSubdomains)
+	subdomains, diag := types.SetValue(types.StringType, imgixSubdomains)
 	if diag.HasError() {
 		return diag
 	}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConvertSourceToSourceModelEnabled(t *testing.T) {
+	enabled := false
+	tests := map[string]struct {
+		enabled  *bool
+		prior    types.Bool
+		expected types.Bool
+	}{
+		"returned":             {enabled: &enabled, prior: types.BoolValue(true), expected: types.BoolValue(false)},
+		"missing keeps prior":  {enabled: nil, prior: types.BoolValue(true), expected: types.BoolValue(true)},
+		"missing without read": {enabled: nil, prior: types.BoolNull(), expected: types.BoolNull()},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &ImgixSource{ID: "abc123", Name: "profile", Enabled: test.enabled}
+			source.Deployment.Type = "s3"
+			state := new(SourceModel)
+			if diags := convertSourceToSourceModel(context.Background(), source, &SourceModel{Enabled: test.prior}, state); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !state.Enabled.Equal(test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, state.Enabled)
+			}
+		})
+	}
+}
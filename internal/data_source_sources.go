@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// With the datasource.DataSource implementation
+func NewSourcesDataSource() datasource.DataSource {
+	return &SourcesDataSource{}
+}
+
+// Ensure the implementation satisfies the datasource.DataSourceWithConfigure interface.
+var _ datasource.DataSourceWithConfigure = &SourcesDataSource{}
+
+type SourcesDataSource struct {
+	client *ImgixClient
+}
+
+type SourcesModel struct {
+	Name       types.String   `tfsdk:"name"`
+	NamePrefix types.String   `tfsdk:"name_prefix"`
+	NameRegex  types.String   `tfsdk:"name_regex"`
+	Enabled    types.Bool     `tfsdk:"enabled"`
+	Type       types.String   `tfsdk:"type"`
+	Subdomain  types.String   `tfsdk:"subdomain"`
+	IDs        []types.String `tfsdk:"ids"`
+	Sources    []SourceModel  `tfsdk:"sources"`
+}
+
+func (d *SourcesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sources"
+}
+
+func (d *SourcesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *SourcesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists every source of the account, optionally filtered. All filters must match.",
+		Attributes: map[string]schema.Attribute{
+			"name":        schema.StringAttribute{Optional: true, MarkdownDescription: "Only return sources with exactly this name."},
+			"name_prefix": schema.StringAttribute{Optional: true, MarkdownDescription: "Only return sources whose name starts with this prefix."},
+			"name_regex":  schema.StringAttribute{Optional: true, MarkdownDescription: "Only return sources whose name matches this regular expression."},
+			"enabled":     schema.BoolAttribute{Optional: true, MarkdownDescription: "Only return enabled or disabled sources."},
+			"type":        schema.StringAttribute{Optional: true, MarkdownDescription: "Only return sources with this deployment type, e.g. `s3`."},
+			"subdomain":   schema.StringAttribute{Optional: true, MarkdownDescription: "Only return sources serving this Imgix subdomain."},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the matching sources.",
+			},
+			"sources": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching sources.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":         schema.StringAttribute{Computed: true},
+						"name":       schema.StringAttribute{Computed: true},
+						"enabled":    schema.BoolAttribute{Computed: true},
+						"deployment": dataDeployObjectType(true, false),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	data := new(SourcesModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		r, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", err.Error())
+			return
+		}
+		nameRegex = r
+	}
+
+	// Let the API filter on the exact name, everything else is filtered here
+	filters := map[string]string{}
+	if !data.Name.IsNull() {
+		filters["name"] = data.Name.ValueString()
+	}
+	sources, err := d.client.ListSources(ctx, filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list sources", err.Error())
+		return
+	}
+
+	data.IDs = []types.String{}
+	data.Sources = []SourceModel{}
+	for _, source := range sources {
+		if !sourceMatchesFilters(source, data, nameRegex) {
+			continue
+		}
+
+		// Convert our remote data into our local model
+		state := new(SourceModel)
+		resp.Diagnostics.Append(convertSourceToSourceModel(ctx, source, new(SourceModel), state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.IDs = append(data.IDs, state.ID)
+		data.Sources = append(data.Sources, *state)
+	}
+
+	// Set the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// sourceMatchesFilters reports whether the source matches every filter that is set.
+func sourceMatchesFilters(source *ImgixSource, filters *SourcesModel, nameRegex *regexp.Regexp) bool {
+	if !filters.Name.IsNull() && source.Name != filters.Name.ValueString() {
+		return false
+	}
+	if !filters.NamePrefix.IsNull() && !strings.HasPrefix(source.Name, filters.NamePrefix.ValueString()) {
+		return false
+	}
+	if nameRegex != nil && !nameRegex.MatchString(source.Name) {
+		return false
+	}
+	if !filters.Enabled.IsNull() && (source.Enabled == nil || *source.Enabled != filters.Enabled.ValueBool()) {
+		return false
+	}
+	if !filters.Type.IsNull() && source.Deployment.Type != filters.Type.ValueString() {
+		return false
+	}
+	if !filters.Subdomain.IsNull() {
+		for _, subdomain := range source.Deployment.ImgixSubdomains {
+			if subdomain == filters.Subdomain.ValueString() {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
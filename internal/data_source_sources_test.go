@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSourceMatchesFilters(t *testing.T) {
+	enabled := true
+	disabled := false
+	nullFilters := SourcesModel{
+		Name:       types.StringNull(),
+		NamePrefix: types.StringNull(),
+		NameRegex:  types.StringNull(),
+		Enabled:    types.BoolNull(),
+		Type:       types.StringNull(),
+		Subdomain:  types.StringNull(),
+	}
+	tests := map[string]struct {
+		enabled   *bool
+		filters   func(*SourcesModel)
+		nameRegex *regexp.Regexp
+		expected  bool
+	}{
+		"no filters": {
+			filters:  func(f *SourcesModel) {},
+			expected: true,
+		},
+		"name": {
+			filters:  func(f *SourcesModel) { f.Name = types.StringValue("profile") },
+			expected: false,
+		},
+		"name prefix matches": {
+			filters:  func(f *SourcesModel) { f.NamePrefix = types.StringValue("prod-") },
+			expected: true,
+		},
+		"name prefix mismatch": {
+			filters:  func(f *SourcesModel) { f.NamePrefix = types.StringValue("staging-") },
+			expected: false,
+		},
+		"name regex matches": {
+			filters:   func(f *SourcesModel) {},
+			nameRegex: regexp.MustCompile(`^prod-.*-images$`),
+			expected:  true,
+		},
+		"name regex mismatch": {
+			filters:   func(f *SourcesModel) {},
+			nameRegex: regexp.MustCompile(`^staging-`),
+			expected:  false,
+		},
+		"enabled matches": {
+			enabled:  &enabled,
+			filters:  func(f *SourcesModel) { f.Enabled = types.BoolValue(true) },
+			expected: true,
+		},
+		"enabled mismatch": {
+			enabled:  &disabled,
+			filters:  func(f *SourcesModel) { f.Enabled = types.BoolValue(true) },
+			expected: false,
+		},
+		"enabled not returned": {
+			filters:  func(f *SourcesModel) { f.Enabled = types.BoolValue(false) },
+			expected: false,
+		},
+		"type matches": {
+			filters:  func(f *SourcesModel) { f.Type = types.StringValue("s3") },
+			expected: true,
+		},
+		"type mismatch": {
+			filters:  func(f *SourcesModel) { f.Type = types.StringValue("gcs") },
+			expected: false,
+		},
+		"subdomain matches": {
+			filters:  func(f *SourcesModel) { f.Subdomain = types.StringValue("prod-images-cdn") },
+			expected: true,
+		},
+		"subdomain mismatch": {
+			filters:  func(f *SourcesModel) { f.Subdomain = types.StringValue("staging-images") },
+			expected: false,
+		},
+		"every filter": {
+			enabled: &enabled,
+			filters: func(f *SourcesModel) {
+				f.NamePrefix = types.StringValue("prod-")
+				f.Enabled = types.BoolValue(true)
+				f.Type = types.StringValue("s3")
+				f.Subdomain = types.StringValue("prod-images")
+			},
+			nameRegex: regexp.MustCompile(`images`),
+			expected:  true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &ImgixSource{ID: "abc123", Name: "prod-web-images", Enabled: test.enabled}
+			source.Deployment.Type = "s3"
+			source.Deployment.ImgixSubdomains = []string{"prod-images", "prod-images-cdn"}
+			filters := nullFilters
+			test.filters(&filters)
+			if matched := sourceMatchesFilters(source, &filters, test.nameRegex); matched != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, matched)
+			}
+		})
+	}
+}
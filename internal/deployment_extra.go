@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// modeledDeploymentFields are the deployment fields managed through the deployment
+// attributes, they can't be overridden through `deployment_extra`.
+var modeledDeploymentFields = []string{
+	"annotation",
+	"imgix_subdomains",
+	"s3_access_key",
+	"s3_bucket",
+	"s3_prefix",
+	"s3_secret_key",
+	"type",
+}
+
+// serverManagedDeploymentFields are set by Imgix and returned along with the deployment,
+// sending them back is rejected or ignored so they're never exported.
+var serverManagedDeploymentFields = []string{
+	"date_created",
+	"date_deployed",
+	"date_modified",
+	"deployment_status",
+	"id",
+	"secure_url_token",
+	"source_id",
+}
+
+// renderingDeploymentFields are the deployment fields that change how images are
+// rendered or cached, changing them leaves stale derivatives at the edge.
+var renderingDeploymentFields = []string{
+	"cache_ttl_behavior",
+	"cache_ttl_error",
+	"cache_ttl_value",
+	"default_params",
+	"image_error",
+	"image_error_append_qs",
+	"image_missing",
+	"image_missing_append_qs",
+}
+
+// decodeDeploymentExtra decodes the JSON object of `deployment_extra`.
+func decodeDeploymentExtra(value types.String) (map[string]interface{}, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	var extra map[string]interface{}
+	if err := json.Unmarshal([]byte(value.ValueString()), &extra); err != nil {
+		return nil, fmt.Errorf("deployment_extra must be a JSON encoded object: %w", err)
+	}
+	var modeled []string
+	for _, field := range modeledDeploymentFields {
+		if _, ok := extra[field]; ok {
+			modeled = append(modeled, field)
+		}
+	}
+	if len(modeled) > 0 {
+		return nil, fmt.Errorf("deployment_extra can't set fields managed by the deployment attributes: %s", strings.Join(modeled, ", "))
+	}
+	return extra, nil
+}
+
+// refreshDeploymentExtra compares the keys set in `deployment_extra` against the remote
+// deployment. The prior value is kept as-is when nothing drifted so formatting doesn't
+// cause diffs, otherwise the remote values of those keys are returned.
+func refreshDeploymentExtra(prior types.String, remote map[string]interface{}) (types.String, error) {
+	extra, err := decodeDeploymentExtra(prior)
+	if err != nil || extra == nil {
+		return prior, err
+	}
+
+	// Only look at the keys we manage, missing keys are dropped so the drift shows in the plan
+	current := make(map[string]interface{}, len(extra))
+	for key := range extra {
+		if value, ok := remote[key]; ok {
+			current[key] = value
+		}
+	}
+	if reflect.DeepEqual(extra, current) {
+		return prior, nil
+	}
+
+	b, err := json.Marshal(current)
+	if err != nil {
+		return prior, err
+	}
+	return types.StringValue(string(b)), nil
+}
+
+// renderingSettingsChanged reports whether any rendering field differs between the two
+// `deployment_extra` values.
+func renderingSettingsChanged(prior, planned types.String) bool {
+	priorExtra, err := decodeDeploymentExtra(prior)
+	if err != nil {
+		return true
+	}
+	plannedExtra, err := decodeDeploymentExtra(planned)
+	if err != nil {
+		return true
+	}
+	for _, field := range renderingDeploymentFields {
+		if !reflect.DeepEqual(priorExtra[field], plannedExtra[field]) {
+			return true
+		}
+	}
+	return false
+}
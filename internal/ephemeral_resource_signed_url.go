@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default lifetime of a signed URL
+const defaultSignedURLTTL = time.Hour
+
+// With the ephemeral.EphemeralResource implementation
+func NewSignedURLEphemeralResource() ephemeral.EphemeralResource {
+	return &SignedURLEphemeralResource{}
+}
+
+// Ensure the implementation satisfies the ephemeral.EphemeralResourceWithConfigure interface.
+var _ ephemeral.EphemeralResourceWithConfigure = &SignedURLEphemeralResource{}
+
+// Ensure the implementation satisfies the ephemeral.EphemeralResourceWithValidateConfig interface.
+var _ ephemeral.EphemeralResourceWithValidateConfig = &SignedURLEphemeralResource{}
+
+type SignedURLEphemeralResource struct {
+	client *ImgixClient
+}
+
+type SignedURLModel struct {
+	SourceID  types.String `tfsdk:"source_id"`
+	Token     types.String `tfsdk:"token"`
+	Domain    types.String `tfsdk:"domain"`
+	Path      types.String `tfsdk:"path"`
+	Params    types.Map    `tfsdk:"params"`
+	TTL       types.String `tfsdk:"ttl"`
+	URL       types.String `tfsdk:"url"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+func (r *SignedURLEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_signed_url"
+}
+
+func (r *SignedURLEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *SignedURLEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Builds a signed imgix URL that expires after `ttl`, using the `expires` parameter. " +
+			"The URL is never stored in the state or the plan.",
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the source whose `secure_url_token` signs the URL. Conflicts with `token`.",
+			},
+			"token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Secure URL token that signs the URL. Conflicts with `source_id`.",
+			},
+			"domain": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Domain of the URL, e.g. `example.imgix.net`. " +
+					"Required with `token`, defaults to the first Imgix subdomain of the source with `source_id`.",
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path of the image, or the full URL of the image for web proxy sources.",
+			},
+			"params": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Rendering parameters, e.g. `{ w = \"400\" }`.",
+			},
+			"ttl": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long the URL stays valid as a duration, e.g. `15m`. Defaults to `1h`.",
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The signed URL.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the URL expires, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func (r *SignedURLEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	data := new(SignedURLModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.SourceID.IsUnknown() || data.Token.IsUnknown() {
+		return
+	}
+	if data.SourceID.IsNull() == data.Token.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_id"),
+			"Invalid Signing Configuration",
+			"Exactly one of source_id or token must be set.",
+		)
+	}
+	if !data.Token.IsNull() && data.Domain.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Missing Domain",
+			"The domain must be set when signing with a token.",
+		)
+	}
+	if !data.TTL.IsNull() && !data.TTL.IsUnknown() {
+		if ttl, err := time.ParseDuration(data.TTL.ValueString()); err != nil || ttl <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ttl"),
+				"Invalid TTL",
+				fmt.Sprintf("The ttl must be a positive duration, e.g. `15m`, got: %q.", data.TTL.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *SignedURLEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// Read Terraform configuration data into the model
+	data := new(SignedURLModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token := data.Token.ValueString()
+	domain := data.Domain.ValueString()
+	if !data.SourceID.IsNull() {
+		// Prevent panic if the provider has not been configured.
+		if r.client == nil {
+			resp.Diagnostics.AddError(
+				"Unconfigured HTTP Client",
+				"Expected configured HTTP client. Please report this issue to the provider developers.",
+			)
+			return
+		}
+		source, err := r.client.GetSourceByID(ctx, data.SourceID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get source", err.Error())
+			return
+		}
+		if source.SecureURLToken == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source_id"),
+				"Missing Secure URL Token",
+				fmt.Sprintf("The source %s doesn't have a secure URL token.", source.ID),
+			)
+			return
+		}
+		token = source.SecureURLToken
+		if domain == "" {
+			if len(source.Deployment.ImgixSubdomains) == 0 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("domain"),
+					"Missing Domain",
+					fmt.Sprintf("The source %s doesn't have any Imgix subdomain, set the domain instead.", source.ID),
+				)
+				return
+			}
+			domain = source.Deployment.ImgixSubdomains[0] + ".imgix.net"
+		}
+	}
+
+	ttl := defaultSignedURLTTL
+	if !data.TTL.IsNull() {
+		parsed, err := time.ParseDuration(data.TTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid TTL", err.Error())
+			return
+		}
+		ttl = parsed
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	params := map[string]string{}
+	if !data.Params.IsNull() {
+		resp.Diagnostics.Append(data.Params.ElementsAs(ctx, &params, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	params["expires"] = strconv.FormatInt(expiresAt.Unix(), 10)
+
+	data.URL = types.StringValue(buildImgixURL(domain, data.Path.ValueString(), params, token))
+	data.ExpiresAt = types.StringValue(expiresAt.UTC().Format(time.RFC3339))
+
+	// Set the result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testSignedURLConfig renders a signed URL configuration with the ephemeral resource schema, unset attributes are null.
+func testSignedURLConfig(t *testing.T, data SignedURLModel) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := new(ephemeral.SchemaResponse)
+	new(SignedURLEphemeralResource).Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+	if data.Params.ElementType(ctx) == nil {
+		data.Params = types.MapNull(types.StringType)
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+}
+
+func TestSignedURLValidateConfig(t *testing.T) {
+	tests := map[string]struct {
+		data     SignedURLModel
+		expected []path.Path
+	}{
+		"source id": {
+			data: SignedURLModel{SourceID: types.StringValue("abc123"), Path: types.StringValue("/a.jpg")},
+		},
+		"token and domain": {
+			data: SignedURLModel{Token: types.StringValue("tok"), Domain: types.StringValue("example.imgix.net"), Path: types.StringValue("/a.jpg")},
+		},
+		"neither": {
+			data:     SignedURLModel{Path: types.StringValue("/a.jpg")},
+			expected: []path.Path{path.Root("source_id")},
+		},
+		"both": {
+			data:     SignedURLModel{SourceID: types.StringValue("abc123"), Token: types.StringValue("tok"), Domain: types.StringValue("example.imgix.net"), Path: types.StringValue("/a.jpg")},
+			expected: []path.Path{path.Root("source_id")},
+		},
+		"token without domain": {
+			data:     SignedURLModel{Token: types.StringValue("tok"), Path: types.StringValue("/a.jpg")},
+			expected: []path.Path{path.Root("domain")},
+		},
+		"invalid ttl": {
+			data:     SignedURLModel{SourceID: types.StringValue("abc123"), Path: types.StringValue("/a.jpg"), TTL: types.StringValue("-5m")},
+			expected: []path.Path{path.Root("ttl")},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := ephemeral.ValidateConfigRequest{Config: testSignedURLConfig(t, test.data)}
+			resp := new(ephemeral.ValidateConfigResponse)
+			new(SignedURLEphemeralResource).ValidateConfig(context.Background(), req, resp)
+
+			if resp.Diagnostics.ErrorsCount() != len(test.expected) {
+				t.Fatalf("expected %d errors, got %v", len(test.expected), resp.Diagnostics)
+			}
+			for i, expected := range test.expected {
+				diag, ok := resp.Diagnostics.Errors()[i].(interface{ Path() path.Path })
+				if !ok || !diag.Path().Equal(expected) {
+					t.Errorf("expected an error on %s, got %v", expected, resp.Diagnostics.Errors()[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSignedURLOpenWithSourceID(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sources/abc123" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		_, _ = w.Write([]byte(`{"data":{"type":"sources","id":"abc123","attributes":{"name":"profile","secure_url_token":"tok","deployment":{"type":"s3","imgix_subdomains":["profile","avatars"]}}}}`))
+	}))
+	resource := &SignedURLEphemeralResource{client: client}
+
+	config := testSignedURLConfig(t, SignedURLModel{
+		SourceID: types.StringValue("abc123"),
+		Path:     types.StringValue("/a.jpg"),
+		Params:   stringMap(t, map[string]string{"w": "400"}),
+		TTL:      types.StringValue("15m"),
+	})
+	schemaResp := new(ephemeral.SchemaResponse)
+	resource.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema}}
+	before := time.Now()
+	resource.Open(ctx, ephemeral.OpenRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	result := new(SignedURLModel)
+	if diags := resp.Result.Get(ctx, result); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	parsed, err := parseImgixURL(result.URL.ValueString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Domain != "profile.imgix.net" || parsed.Path != "/a.jpg" || parsed.Params["w"] != "400" {
+		t.Errorf("expected the URL to default to the first subdomain, got %s", result.URL.ValueString())
+	}
+	if !parsed.validSignature("tok") {
+		t.Errorf("expected the URL to be signed with the source token, got %s", result.URL.ValueString())
+	}
+
+	expires, err := strconv.ParseInt(parsed.Params["expires"], 10, 64)
+	if err != nil {
+		t.Fatalf("expected an expires param, got %s", result.URL.ValueString())
+	}
+	ttl := time.Unix(expires, 0).Sub(before)
+	if ttl < 14*time.Minute || ttl > 16*time.Minute {
+		t.Errorf("expected the URL to expire in 15m, got %s", ttl)
+	}
+	if result.ExpiresAt.ValueString() != time.Unix(expires, 0).UTC().Format(time.RFC3339) {
+		t.Errorf("expires_at = %s, want it to match the expires param %d", result.ExpiresAt.ValueString(), expires)
+	}
+}
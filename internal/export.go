@@ -0,0 +1,281 @@
+package internal
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Files written by the export, kept apart from any hand-written configuration
+const (
+	exportSourcesFile   = "imgixyz_sources.tf"
+	exportVariablesFile = "imgixyz_variables.tf"
+)
+
+var (
+	hclIdentifier   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	invalidLabelRun = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+// hclAttribute is an attribute of a block or object, its value already rendered.
+type hclAttribute struct {
+	Name  string
+	Value string
+}
+
+// Export lists every source of the account and writes them as imgixyz_source resources
+// with import blocks. Secrets are never returned by Imgix, they're replaced by variables.
+func Export(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	out := flags.String("out", ".", "directory to write "+exportSourcesFile+" and "+exportVariablesFile+" to")
+	force := flags.Bool("force", false, "overwrite the files if they already exist")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: terraform-provider-imgixyz export [-out dir] [-force]")
+		fmt.Fprintln(flags.Output(), "\nWrites every source as Terraform configuration, using the token in IMGIXYZ_TOKEN.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	token := os.Getenv("IMGIXYZ_TOKEN")
+	if token == "" {
+		return fmt.Errorf("the token was not found in the IMGIXYZ_TOKEN environment variable")
+	}
+	client := NewImgixClient(token, false)
+	sources, err := client.ListSources(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list sources: %w", err)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Name != sources[j].Name {
+			return sources[i].Name < sources[j].Name
+		}
+		return sources[i].ID < sources[j].ID
+	})
+
+	configuration, variables := renderSourcesExport(sources)
+	files := map[string]string{
+		filepath.Join(*out, exportSourcesFile):   configuration,
+		filepath.Join(*out, exportVariablesFile): variables,
+	}
+	if !*force {
+		for name := range files {
+			if _, err := os.Stat(name); err == nil {
+				return fmt.Errorf("%s already exists, use -force to overwrite it", name)
+			}
+		}
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d sources to %s\n", len(sources), *out)
+	return nil
+}
+
+// renderSourcesExport returns the configuration of the sources and the variables it uses.
+func renderSourcesExport(sources []*ImgixSource) (string, string) {
+	var configuration, variables strings.Builder
+	labels := map[string]bool{}
+	for _, source := range sources {
+		label := uniqueExportLabel(source.Name, labels)
+		secret := func(field string) string {
+			name := label + "_" + field
+			fmt.Fprintf(&variables, "variable %s {\n  type      = string\n  sensitive = true\n}\n\n", hclString(name))
+			return "var." + name
+		}
+
+		deployment := source.Deployment
+		config := []hclAttribute{
+			{"type", hclString(deployment.Type)},
+			{"annotation", hclString(deployment.Annotation)},
+			{"imgix_subdomains", hclValue(stringsToInterfaces(deployment.ImgixSubdomains), 1)},
+		}
+		if deployment.Type == "s3" {
+			config = append(config,
+				hclAttribute{"s3_bucket", hclString(deployment.S3Bucket)},
+				hclAttribute{"s3_access_key", secret("s3_access_key")},
+				hclAttribute{"s3_secret_key", secret("s3_secret_key")},
+			)
+			if deployment.S3Prefix != nil {
+				config = append(config, hclAttribute{"s3_prefix", hclString(*deployment.S3Prefix)})
+			}
+		}
+
+		// Everything else that can be set, e.g. the settings of other source types, goes in deployment_extra
+		var extra []hclAttribute
+		for _, key := range sortedKeys(deployment.Raw) {
+			value := deployment.Raw[key]
+			if value == nil || containsString(modeledDeploymentFields, key) || containsString(serverManagedDeploymentFields, key) {
+				continue
+			}
+			if isSecretDeploymentField(key) {
+				extra = append(extra, hclAttribute{hclKey(key), secret(key)})
+			} else {
+				extra = append(extra, hclAttribute{hclKey(key), hclValue(value, 2)})
+			}
+		}
+
+		// Don't guess enabled when imgix didn't return it, the plan then asks for it
+		attributes := []hclAttribute{{"name", hclString(source.Name)}}
+		if source.Enabled != nil {
+			attributes = append(attributes, hclAttribute{"enabled", strconv.FormatBool(*source.Enabled)})
+		}
+		fmt.Fprintf(&configuration, "resource \"imgixyz_source\" %s {\n", hclString(label))
+		writeHCLAttributes(&configuration, attributes, 1)
+		if source.Enabled == nil {
+			configuration.WriteString("  # enabled wasn't returned by imgix, set it before applying\n")
+		}
+		configuration.WriteString("\n  deployment_config = {\n")
+		writeHCLAttributes(&configuration, config, 2)
+		configuration.WriteString("  }\n")
+		if len(extra) > 0 {
+			var object strings.Builder
+			writeHCLAttributes(&object, extra, 2)
+			fmt.Fprintf(&configuration, "\n  deployment_extra = jsonencode({\n%s  })\n", object.String())
+		}
+		configuration.WriteString("}\n\n")
+		fmt.Fprintf(&configuration, "import {\n  to = imgixyz_source.%s\n  id = %s\n}\n\n", label, hclString(source.ID))
+	}
+	return strings.TrimSuffix(configuration.String(), "\n"), strings.TrimSuffix(variables.String(), "\n")
+}
+
+// uniqueExportLabel turns the name into a resource label that wasn't used yet.
+func uniqueExportLabel(name string, used map[string]bool) string {
+	label := strings.Trim(invalidLabelRun.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		label = "source"
+	} else if label[0] >= '0' && label[0] <= '9' {
+		label = "source_" + label
+	}
+	unique := label
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// isSecretDeploymentField reports whether the deployment field holds credentials.
+func isSecretDeploymentField(key string) bool {
+	for _, secret := range []string{"secret", "password", "private_key", "credentials", "access_key", "token"} {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeHCLAttributes writes the attributes with their equal signs aligned, like `terraform fmt`.
+func writeHCLAttributes(b *strings.Builder, attributes []hclAttribute, depth int) {
+	width := 0
+	for _, attribute := range attributes {
+		if len(attribute.Name) > width {
+			width = len(attribute.Name)
+		}
+	}
+	indent := strings.Repeat("  ", depth)
+	for _, attribute := range attributes {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, attribute.Name, attribute.Value)
+	}
+}
+
+// hclValue renders a decoded JSON value as an HCL expression.
+func hclValue(value interface{}, depth int) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return hclString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		elements := make([]string, 0, len(v))
+		for _, element := range v {
+			elements = append(elements, hclValue(element, depth))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+		attributes := make([]hclAttribute, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			attributes = append(attributes, hclAttribute{hclKey(key), hclValue(v[key], depth+1)})
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		writeHCLAttributes(&b, attributes, depth+1)
+		b.WriteString(strings.Repeat("  ", depth) + "}")
+		return b.String()
+	default:
+		return hclString(fmt.Sprint(v))
+	}
+}
+
+// hclKey returns the key of an object, quoted unless it's a valid identifier.
+func hclKey(key string) string {
+	if hclIdentifier.MatchString(key) {
+		return key
+	}
+	return hclString(key)
+}
+
+// hclString quotes the string, escaping template sequences so it's taken literally.
+func hclString(s string) string {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "${", "$${"), "%{", "%%{")
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSourcesExportDeploymentExtra(t *testing.T) {
+	enabled := true
+	source := &ImgixSource{ID: "abc123", Name: "Profile pictures", Enabled: &enabled}
+	source.Deployment.Type = "gcs"
+	source.Deployment.Annotation = "profiles"
+	source.Deployment.ImgixSubdomains = []string{"profiles"}
+	source.Deployment.Raw = map[string]interface{}{
+		"type":              "gcs",
+		"annotation":        "profiles",
+		"imgix_subdomains":  []interface{}{"profiles"},
+		"gcs_bucket":        "profile-pictures",
+		"gcs_private_key":   "********",
+		"cache_ttl_value":   float64(3600),
+		"custom_domains":    nil,
+		"date_deployed":     float64(1760000000),
+		"deployment_status": "deployed",
+		"secure_url_token":  "hunter2",
+		"id":                "abc123",
+	}
+
+	configuration, variables := renderSourcesExport([]*ImgixSource{source})
+	expected := `  deployment_extra = jsonencode({
+    cache_ttl_value = 3600
+    gcs_bucket      = "profile-pictures"
+    gcs_private_key = var.profile_pictures_gcs_private_key
+  })`
+	if !strings.Contains(configuration, expected) {
+		t.Errorf("expected the configuration to contain\n%s\ngot\n%s", expected, configuration)
+	}
+	for _, key := range []string{"date_deployed", "deployment_status", "secure_url_token", "hunter2", "custom_domains"} {
+		if strings.Contains(configuration, key) {
+			t.Errorf("expected %s not to be exported, got\n%s", key, configuration)
+		}
+	}
+	if !strings.Contains(variables, `variable "profile_pictures_gcs_private_key"`) {
+		t.Errorf("expected a variable for the private key, got\n%s", variables)
+	}
+}
+
+func TestRenderSourcesExportEnabled(t *testing.T) {
+	disabled := false
+	tests := map[string]struct {
+		enabled  *bool
+		expected string
+	}{
+		"returned": {
+			enabled: &disabled,
+			expected: `  name    = "profile"
+  enabled = false
+`,
+		},
+		"missing": {
+			expected: `  name = "profile"
+  # enabled wasn't returned by imgix, set it before applying
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &ImgixSource{ID: "abc123", Name: "profile", Enabled: test.enabled}
+			source.Deployment.Type = "s3"
+			source.Deployment.ImgixSubdomains = []string{"profile"}
+
+			configuration, _ := renderSourcesExport([]*ImgixSource{source})
+			if !strings.Contains(configuration, test.expected) {
+				t.Errorf("expected the configuration to contain\n%s\ngot\n%s", test.expected, configuration)
+			}
+			if test.enabled == nil && strings.Contains(configuration, "enabled =") {
+				t.Errorf("expected enabled not to be exported, got\n%s", configuration)
+			}
+		})
+	}
+}
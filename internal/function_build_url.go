@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// With the function.Function implementation
+func NewBuildURLFunction() function.Function {
+	return &BuildURLFunction{}
+}
+
+// Ensure the implementation satisfies the function.Function interface.
+var _ function.Function = &BuildURLFunction{}
+
+type BuildURLFunction struct{}
+
+func (f *BuildURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_url"
+}
+
+func (f *BuildURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds an imgix URL",
+		MarkdownDescription: "Builds an imgix URL with its rendering parameters sorted and encoded. " +
+			"Parameters ending in `64`, e.g. `txt64` or `mark64`, are base64 encoded. " +
+			"An optional map of default parameters, e.g. the `default_params` of the source, is applied beneath `params`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Domain of the source, e.g. `example.imgix.net`.",
+			},
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the image, or the full URL of the image for web proxy sources.",
+			},
+			function.MapParameter{
+				Name:                "params",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+				MarkdownDescription: "Rendering parameters, e.g. `{ w = \"400\" }`.",
+			},
+		},
+		VariadicParameter: function.MapParameter{
+			Name:                "default_params",
+			ElementType:         types.StringType,
+			AllowNullValue:      true,
+			MarkdownDescription: "Default parameters, overridden by `params`. At most one map can be given.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *BuildURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain, path string
+	var params types.Map
+	var defaults []types.Map
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domain, &path, &params, &defaults))
+	if resp.Error != nil {
+		return
+	}
+	if len(defaults) > 1 {
+		resp.Error = function.NewArgumentFuncError(4, "at most one map of default_params can be given")
+		return
+	}
+
+	values, funcErr := mergeImgixParams(ctx, params, defaults...)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, buildImgixURL(domain, path, values, "")))
+}
+
+// mergeImgixParams merges the parameters on top of the defaults.
+func mergeImgixParams(ctx context.Context, params types.Map, defaults ...types.Map) (map[string]string, *function.FuncError) {
+	values := map[string]string{}
+	for _, m := range append(defaults, params) {
+		current := map[string]string{}
+		if diags := m.ElementsAs(ctx, &current, false); diags.HasError() {
+			return nil, function.FuncErrorFromDiags(ctx, diags)
+		}
+		for key, value := range current {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction calls the function with the arguments, the variadic ones given as a tuple,
+// and returns its result.
+func runFunction(t *testing.T, f function.Function, returnType attr.Value, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData(arguments)}
+	resp := &function.RunResponse{Result: function.NewResultData(returnType)}
+	f.Run(context.Background(), req, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func stringMap(t *testing.T, values map[string]string) types.Map {
+	t.Helper()
+	if values == nil {
+		return types.MapNull(types.StringType)
+	}
+	m, diags := types.MapValueFrom(context.Background(), types.StringType, values)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return m
+}
+
+func stringMapTuple(t *testing.T, maps ...map[string]string) types.Tuple {
+	t.Helper()
+	elementTypes := make([]attr.Type, 0, len(maps))
+	elements := make([]attr.Value, 0, len(maps))
+	for _, m := range maps {
+		elementTypes = append(elementTypes, types.MapType{ElemType: types.StringType})
+		elements = append(elements, stringMap(t, m))
+	}
+	tuple, diags := types.TupleValue(elementTypes, elements)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return tuple
+}
+
+func TestBuildURLFunction(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		params   map[string]string
+		defaults []map[string]string
+		expected string
+	}{
+		"no params": {
+			path:     "/users/1.png",
+			expected: "https://example.imgix.net/users/1.png",
+		},
+		"sorted and encoded": {
+			path:     "/users/1.png",
+			params:   map[string]string{"w": "400", "fit": "crop", "txt": "Hello, world"},
+			expected: "https://example.imgix.net/users/1.png?fit=crop&txt=Hello%2C%20world&w=400",
+		},
+		"base64": {
+			path:     "/users/1.png",
+			params:   map[string]string{"txt64": "Hello, world", "mark64": "https://assets.imgix.net/logo.png"},
+			expected: "https://example.imgix.net/users/1.png?mark64=aHR0cHM6Ly9hc3NldHMuaW1naXgubmV0L2xvZ28ucG5n&txt64=SGVsbG8sIHdvcmxk",
+		},
+		"defaults beneath params": {
+			path:     "/users/1.png",
+			params:   map[string]string{"w": "400"},
+			defaults: []map[string]string{{"w": "100", "auto": "format"}},
+			expected: "https://example.imgix.net/users/1.png?auto=format&w=400",
+		},
+		"null defaults": {
+			path:     "/users/1.png",
+			params:   map[string]string{"w": "400"},
+			defaults: []map[string]string{nil},
+			expected: "https://example.imgix.net/users/1.png?w=400",
+		},
+		"web proxy": {
+			path:     "https://avatars.com/john smith.png",
+			params:   map[string]string{"w": "400"},
+			expected: "https://example.imgix.net/https%3A%2F%2Favatars.com%2Fjohn%20smith.png?w=400",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := runFunction(t, NewBuildURLFunction(), types.StringUnknown(),
+				types.StringValue("https://example.imgix.net/"), types.StringValue(test.path),
+				stringMap(t, test.params), stringMapTuple(t, test.defaults...))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !result.Equal(types.StringValue(test.expected)) {
+				t.Errorf("expected %s, got %s", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestBuildURLFunctionErrors(t *testing.T) {
+	_, err := runFunction(t, NewBuildURLFunction(), types.StringUnknown(),
+		types.StringValue("example.imgix.net"), types.StringValue("/users/1.png"), stringMap(t, nil),
+		stringMapTuple(t, map[string]string{"w": "1"}, map[string]string{"h": "1"}))
+	if err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 4 || !strings.Contains(err.Text, "at most one map") {
+		t.Errorf("expected an error on the default_params argument, got %v", err)
+	}
+}
+
+func TestSignURLFunctionErrors(t *testing.T) {
+	_, err := runFunction(t, NewSignURLFunction(), types.StringUnknown(),
+		types.StringValue("example.imgix.net"), types.StringValue("/users/1.png"), stringMap(t, nil), types.StringValue(""))
+	if err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 3 {
+		t.Errorf("expected an error on the token argument, got %v", err)
+	}
+}
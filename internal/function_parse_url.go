@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// With the function.Function implementation
+func NewParseURLFunction() function.Function {
+	return &ParseURLFunction{}
+}
+
+// Ensure the implementation satisfies the function.Function interface.
+var _ function.Function = &ParseURLFunction{}
+
+type ParseURLFunction struct{}
+
+type ParsedURLModel struct {
+	Domain         types.String `tfsdk:"domain"`
+	Path           types.String `tfsdk:"path"`
+	Params         types.Map    `tfsdk:"params"`
+	Signed         types.Bool   `tfsdk:"signed"`
+	SignatureValid types.Bool   `tfsdk:"signature_valid"`
+}
+
+var parsedURLAttributeTypes = map[string]attr.Type{
+	"domain":          types.StringType,
+	"path":            types.StringType,
+	"params":          types.MapType{ElemType: types.StringType},
+	"signed":          types.BoolType,
+	"signature_valid": types.BoolType,
+}
+
+func (f *ParseURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_url"
+}
+
+func (f *ParseURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses an imgix URL",
+		MarkdownDescription: "Splits an imgix URL into its `domain`, decoded `path` and decoded `params`, without the `s` signature. " +
+			"Parameters ending in `64`, e.g. `txt64`, are base64 decoded. " +
+			"`signed` tells whether the URL has a signature, and `signature_valid` whether it matches the optional token, " +
+			"it is null when no token is given.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The imgix URL, e.g. `https://example.imgix.net/image.png?w=400`.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "token",
+			MarkdownDescription: "Secure URL token of the source to check the signature against. At most one token can be given.",
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedURLAttributeTypes,
+		},
+	}
+}
+
+func (f *ParseURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawURL string
+	var tokens []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &rawURL, &tokens))
+	if resp.Error != nil {
+		return
+	}
+	if len(tokens) > 1 {
+		resp.Error = function.NewArgumentFuncError(1, "at most one token can be given")
+		return
+	}
+
+	parsed, err := parseImgixURL(rawURL)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to parse the imgix URL: "+err.Error())
+		return
+	}
+
+	result := ParsedURLModel{
+		Domain:         types.StringValue(parsed.Domain),
+		Path:           types.StringValue(parsed.Path),
+		Signed:         types.BoolValue(parsed.Signature != ""),
+		SignatureValid: types.BoolNull(),
+	}
+	if len(tokens) == 1 {
+		result.SignatureValid = types.BoolValue(parsed.validSignature(tokens[0]))
+	}
+	params, diags := types.MapValueFrom(ctx, types.StringType, parsed.Params)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	result.Params = params
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func parseURLResult(t *testing.T, arguments ...attr.Value) ParsedURLModel {
+	t.Helper()
+	result, err := runFunction(t, NewParseURLFunction(), types.ObjectUnknown(parsedURLAttributeTypes), arguments...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var parsed ParsedURLModel
+	if diags := result.(types.Object).As(context.Background(), &parsed, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return parsed
+}
+
+func stringTuple(values ...string) types.Tuple {
+	elementTypes := make([]attr.Type, 0, len(values))
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elementTypes = append(elementTypes, types.StringType)
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.TupleValueMust(elementTypes, elements)
+}
+
+func TestParseURLFunctionRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		path   string
+		params map[string]string
+		token  string
+	}{
+		"plain": {
+			path:   "/users/1.png",
+			params: map[string]string{"w": "400", "fit": "crop"},
+		},
+		"encoded path and params": {
+			path:   "/images/hello world+1.png",
+			params: map[string]string{"txt": "50% off & more", "blend": "#FF0000"},
+		},
+		"base64 params": {
+			path:   "/users/1.png",
+			params: map[string]string{"txt64": "I cannøt belîév∑ it wors! 😱", "mark64": "https://assets.imgix.net/logo.png?w=10"},
+		},
+		"signed": {
+			path:   "/users/1.png",
+			params: map[string]string{"w": "400", "txt64": "Hello, world"},
+			token:  "FOO123bar",
+		},
+		"signed web proxy": {
+			path:   "http://avatars.com/john-smith.png",
+			params: map[string]string{},
+			token:  "FOO123bar",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			built := buildImgixURL("example.imgix.net", test.path, test.params, test.token)
+			arguments := []attr.Value{types.StringValue(built), stringTuple()}
+			if test.token != "" {
+				arguments[1] = stringTuple(test.token)
+			}
+			parsed := parseURLResult(t, arguments...)
+
+			if parsed.Domain.ValueString() != "example.imgix.net" {
+				t.Errorf("expected the domain example.imgix.net, got %s", parsed.Domain)
+			}
+			if expected := "/" + strings.TrimPrefix(test.path, "/"); parsed.Path.ValueString() != expected {
+				t.Errorf("expected the path %s, got %s", expected, parsed.Path)
+			}
+			params := map[string]string{}
+			if diags := parsed.Params.ElementsAs(context.Background(), &params, false); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(params, test.params) {
+				t.Errorf("expected the params %v, got %v", test.params, params)
+			}
+			if parsed.Signed.ValueBool() != (test.token != "") {
+				t.Errorf("expected signed to be %t, got %s", test.token != "", parsed.Signed)
+			}
+			if test.token == "" && !parsed.SignatureValid.IsNull() {
+				t.Errorf("expected signature_valid to be null without a token, got %s", parsed.SignatureValid)
+			}
+			if test.token != "" && !parsed.SignatureValid.Equal(types.BoolValue(true)) {
+				t.Errorf("expected signature_valid to be true, got %s", parsed.SignatureValid)
+			}
+		})
+	}
+}
+
+func TestParseURLFunctionSignature(t *testing.T) {
+	signed := "https://my-social-network.imgix.net/users/1.png?s=6797c24146142d5b40bde3141fd3600c"
+	tests := map[string]struct {
+		url      string
+		token    string
+		expected bool
+	}{
+		"valid":          {url: signed, token: "FOO123bar", expected: true},
+		"wrong token":    {url: signed, token: "other", expected: false},
+		"tampered":       {url: strings.Replace(signed, "1.png", "2.png", 1), token: "FOO123bar", expected: false},
+		"param appended": {url: signed + "&w=400", token: "FOO123bar", expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parsed := parseURLResult(t, types.StringValue(test.url), stringTuple(test.token))
+			if !parsed.SignatureValid.Equal(types.BoolValue(test.expected)) {
+				t.Errorf("expected signature_valid to be %t, got %s", test.expected, parsed.SignatureValid)
+			}
+		})
+	}
+}
+
+func TestParseURLFunctionErrors(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		tokens   types.Tuple
+		argument int64
+		expected string
+	}{
+		"relative URL": {
+			url:      "/users/1.png",
+			tokens:   stringTuple(),
+			argument: 0,
+			expected: "Unable to parse the imgix URL",
+		},
+		"invalid base64": {
+			url:      "https://example.imgix.net/users/1.png?txt64=%%%",
+			tokens:   stringTuple(),
+			argument: 0,
+			expected: "Unable to parse the imgix URL",
+		},
+		"invalid base64 value": {
+			url:      "https://example.imgix.net/users/1.png?txt64=a",
+			tokens:   stringTuple(),
+			argument: 0,
+			expected: "invalid base64 value for txt64",
+		},
+		"several tokens": {
+			url:      "https://example.imgix.net/users/1.png",
+			tokens:   stringTuple("a", "b"),
+			argument: 1,
+			expected: "at most one token can be given",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := runFunction(t, NewParseURLFunction(), types.ObjectUnknown(parsedURLAttributeTypes), types.StringValue(test.url), test.tokens)
+			if err == nil || err.FunctionArgument == nil || *err.FunctionArgument != test.argument || !strings.Contains(err.Text, test.expected) {
+				t.Errorf("expected %q on argument %d, got %v", test.expected, test.argument, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// With the function.Function implementation
+func NewSignURLFunction() function.Function {
+	return &SignURLFunction{}
+}
+
+// Ensure the implementation satisfies the function.Function interface.
+var _ function.Function = &SignURLFunction{}
+
+type SignURLFunction struct{}
+
+func (f *SignURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sign_url"
+}
+
+func (f *SignURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds a signed imgix URL",
+		MarkdownDescription: "Builds an imgix URL signed with the `secure_url_token` of its source, appending the MD5 signature as `s=`. " +
+			"Parameters ending in `64`, e.g. `txt64`, are base64 encoded.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Domain of the source, e.g. `example.imgix.net`.",
+			},
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the image, or the full URL of the image for web proxy sources.",
+			},
+			function.MapParameter{
+				Name:                "params",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+				MarkdownDescription: "Rendering parameters, e.g. `{ w = \"400\" }`.",
+			},
+			function.StringParameter{
+				Name:                "token",
+				MarkdownDescription: "Secure URL token of the source.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SignURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain, path, token string
+	var params types.Map
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domain, &path, &params, &token))
+	if resp.Error != nil {
+		return
+	}
+	if token == "" {
+		resp.Error = function.NewArgumentFuncError(3, "token must not be empty")
+		return
+	}
+
+	values, funcErr := mergeImgixParams(ctx, params)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, buildImgixURL(domain, path, values, token)))
+}
@@ -0,0 +1,350 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Defaults of the imgix libraries
+const (
+	srcsetMinWidth       = 100
+	srcsetMaxWidth       = 8192
+	srcsetWidthTolerance = 0.08
+)
+
+// Quality used for each device pixel ratio, lower on denser screens
+var srcsetDPRQualities = map[float64]int64{1: 75, 2: 50, 3: 35, 4: 23, 5: 20}
+
+// SrcsetOptions are the options accepted by the srcset function.
+type SrcsetOptions struct {
+	Widths                 []int64
+	MinWidth               int64
+	MaxWidth               int64
+	WidthTolerance         float64
+	DevicePixelRatios      []float64
+	VariableQualities      map[float64]int64
+	DisableVariableQuality bool
+	Token                  string
+}
+
+// With the function.Function implementation
+func NewSrcsetFunction() function.Function {
+	return &SrcsetFunction{}
+}
+
+// Ensure the implementation satisfies the function.Function interface.
+var _ function.Function = &SrcsetFunction{}
+
+type SrcsetFunction struct{}
+
+func (f *SrcsetFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "srcset"
+}
+
+func (f *SrcsetFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds an imgix srcset",
+		MarkdownDescription: "Builds a `srcset` the same way the imgix libraries do. " +
+			"When `params` has a `w` or `h`, the srcset lists device pixel ratios from 1x to 5x with variable quality, " +
+			"otherwise it lists widths from 100 to 8192 pixels, each 8% apart.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Domain of the source, e.g. `example.imgix.net`.",
+			},
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the image, or the full URL of the image for web proxy sources.",
+			},
+			function.MapParameter{
+				Name:                "params",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+				MarkdownDescription: "Rendering parameters, e.g. `{ w = \"400\" }`.",
+			},
+			function.DynamicParameter{
+				Name:           "options",
+				AllowNullValue: true,
+				MarkdownDescription: "Object of options, all optional: " +
+					"`widths` (list of widths to use instead of a range), " +
+					"`min_width`, `max_width` and `width_tolerance` (range of widths, defaults to `100`, `8192` and `0.08`), " +
+					"`device_pixel_ratios` (defaults to `[1, 2, 3, 4, 5]`), " +
+					"`variable_qualities` (map of device pixel ratio to quality, defaults to `{ 1 = 75, 2 = 50, 3 = 35, 4 = 23, 5 = 20 }`), " +
+					"`disable_variable_quality` and `token` (signs every URL with this secure URL token).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SrcsetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain, path string
+	var params types.Map
+	var options types.Dynamic
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domain, &path, &params, &options))
+	if resp.Error != nil {
+		return
+	}
+
+	values, funcErr := mergeImgixParams(ctx, params)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	opts, err := parseSrcsetOptions(options)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(3, err.Error())
+		return
+	}
+
+	var srcset string
+	if values["w"] != "" || values["h"] != "" {
+		srcset = buildDPRSrcset(domain, path, values, opts)
+	} else {
+		widths := opts.Widths
+		if widths == nil {
+			widths = srcsetTargetWidths(opts.MinWidth, opts.MaxWidth, opts.WidthTolerance)
+		}
+		srcset = buildWidthSrcset(domain, path, values, widths, opts.Token)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, srcset))
+}
+
+// srcsetTargetWidths returns the widths between min and max, each one `tolerance` apart.
+func srcsetTargetWidths(min, max int64, tolerance float64) []int64 {
+	if min == max {
+		return []int64{min}
+	}
+	widths := []int64{}
+	for width := float64(min); width < float64(max); width *= 1 + tolerance*2 {
+		widths = append(widths, int64(math.Round(width)))
+	}
+	if widths[len(widths)-1] < max {
+		widths = append(widths, max)
+	}
+	return widths
+}
+
+func buildWidthSrcset(domain, path string, params map[string]string, widths []int64, token string) string {
+	entries := make([]string, 0, len(widths))
+	for _, width := range widths {
+		values := copyImgixParams(params)
+		values["w"] = strconv.FormatInt(width, 10)
+		entries = append(entries, fmt.Sprintf("%s %dw", buildImgixURL(domain, path, values, token), width))
+	}
+	return strings.Join(entries, ",\n")
+}
+
+func buildDPRSrcset(domain, path string, params map[string]string, opts *SrcsetOptions) string {
+	entries := make([]string, 0, len(opts.DevicePixelRatios))
+	for _, dpr := range opts.DevicePixelRatios {
+		ratio := strconv.FormatFloat(dpr, 'f', -1, 64)
+		values := copyImgixParams(params)
+		values["dpr"] = ratio
+		// An explicit quality always wins over the variable one
+		if quality, ok := opts.VariableQualities[dpr]; ok && !opts.DisableVariableQuality && params["q"] == "" {
+			values["q"] = strconv.FormatInt(quality, 10)
+		}
+		entries = append(entries, fmt.Sprintf("%s %sx", buildImgixURL(domain, path, values, opts.Token), ratio))
+	}
+	return strings.Join(entries, ",\n")
+}
+
+func copyImgixParams(params map[string]string) map[string]string {
+	values := make(map[string]string, len(params)+2)
+	for key, value := range params {
+		values[key] = value
+	}
+	return values
+}
+
+// parseSrcsetOptions validates the options object and fills in the defaults.
+func parseSrcsetOptions(options types.Dynamic) (*SrcsetOptions, error) {
+	opts := &SrcsetOptions{
+		MinWidth:          srcsetMinWidth,
+		MaxWidth:          srcsetMaxWidth,
+		WidthTolerance:    srcsetWidthTolerance,
+		VariableQualities: map[float64]int64{},
+	}
+	for dpr, quality := range srcsetDPRQualities {
+		opts.DevicePixelRatios = append(opts.DevicePixelRatios, dpr)
+		opts.VariableQualities[dpr] = quality
+	}
+	sort.Float64s(opts.DevicePixelRatios)
+
+	raw, err := dynamicValue(options)
+	if err != nil || raw == nil {
+		return opts, err
+	}
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("options must be an object")
+	}
+
+	for key, value := range fields {
+		if value == nil {
+			continue
+		}
+		switch key {
+		case "widths":
+			widths, err := numberList(key, value)
+			if err != nil {
+				return nil, err
+			}
+			opts.Widths = []int64{}
+			for _, width := range widths {
+				if width <= 0 || width != math.Trunc(width) {
+					return nil, fmt.Errorf("widths must be positive integers")
+				}
+				opts.Widths = append(opts.Widths, int64(width))
+			}
+		case "min_width", "max_width":
+			width, ok := value.(float64)
+			if !ok || width < 1 {
+				return nil, fmt.Errorf("%s must be a positive number", key)
+			}
+			if key == "min_width" {
+				opts.MinWidth = int64(width)
+			} else {
+				opts.MaxWidth = int64(width)
+			}
+		case "width_tolerance":
+			tolerance, ok := value.(float64)
+			if !ok || tolerance < 0.01 {
+				return nil, fmt.Errorf("width_tolerance must be a number of at least 0.01")
+			}
+			opts.WidthTolerance = tolerance
+		case "device_pixel_ratios":
+			ratios, err := numberList(key, value)
+			if err != nil {
+				return nil, err
+			}
+			for _, ratio := range ratios {
+				if ratio <= 0 {
+					return nil, fmt.Errorf("device_pixel_ratios must be positive numbers")
+				}
+			}
+			opts.DevicePixelRatios = ratios
+		case "variable_qualities":
+			qualities, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("variable_qualities must be a map of device pixel ratio to quality")
+			}
+			for ratio, quality := range qualities {
+				dpr, err := strconv.ParseFloat(ratio, 64)
+				q, ok := quality.(float64)
+				if err != nil || !ok {
+					return nil, fmt.Errorf("variable_qualities must be a map of device pixel ratio to quality")
+				}
+				opts.VariableQualities[dpr] = int64(q)
+			}
+		case "disable_variable_quality":
+			disable, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("disable_variable_quality must be a bool")
+			}
+			opts.DisableVariableQuality = disable
+		case "token":
+			token, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("token must be a string")
+			}
+			opts.Token = token
+		default:
+			return nil, fmt.Errorf("unsupported option %q", key)
+		}
+	}
+
+	if opts.MinWidth > opts.MaxWidth {
+		return nil, fmt.Errorf("min_width must not be greater than max_width")
+	}
+	return opts, nil
+}
+
+func numberList(key string, value interface{}) ([]float64, error) {
+	elements, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of numbers", key)
+	}
+	numbers := make([]float64, 0, len(elements))
+	for _, element := range elements {
+		number, ok := element.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of numbers", key)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// dynamicValue converts a dynamic value into plain Go values: strings, float64, bools,
+// slices and maps.
+func dynamicValue(value attr.Value) (interface{}, error) {
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value must be known")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	var elements []attr.Value
+	var fields map[string]attr.Value
+	isObject := false
+	switch v := value.(type) {
+	case types.Dynamic:
+		return dynamicValue(v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Number:
+		n, _ := v.ValueBigFloat().Float64()
+		return n, nil
+	case types.Int64:
+		return float64(v.ValueInt64()), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.List:
+		elements = v.Elements()
+	case types.Set:
+		elements = v.Elements()
+	case types.Tuple:
+		elements = v.Elements()
+	case types.Map:
+		fields, isObject = v.Elements(), true
+	case types.Object:
+		fields, isObject = v.Attributes(), true
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", value.Type(context.Background()))
+	}
+
+	if isObject {
+		result := make(map[string]interface{}, len(fields))
+		for key, field := range fields {
+			converted, err := dynamicValue(field)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	}
+	result := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		converted, err := dynamicValue(element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
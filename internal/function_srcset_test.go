@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func srcsetOptions(t *testing.T, attributes map[string]attr.Value) types.Dynamic {
+	t.Helper()
+	if attributes == nil {
+		return types.DynamicNull()
+	}
+	attributeTypes := make(map[string]attr.Type, len(attributes))
+	for name, value := range attributes {
+		attributeTypes[name] = value.Type(context.Background())
+	}
+	object, diags := types.ObjectValue(attributeTypes, attributes)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return types.DynamicValue(object)
+}
+
+func numberTuple(numbers ...int64) types.Tuple {
+	elementTypes := make([]attr.Type, 0, len(numbers))
+	elements := make([]attr.Value, 0, len(numbers))
+	for _, n := range numbers {
+		elementTypes = append(elementTypes, types.Int64Type)
+		elements = append(elements, types.Int64Value(n))
+	}
+	return types.TupleValueMust(elementTypes, elements)
+}
+
+func TestSrcsetTargetWidths(t *testing.T) {
+	tests := map[string]struct {
+		min, max  int64
+		tolerance float64
+		expected  []int64
+	}{
+		"defaults": {
+			min: srcsetMinWidth, max: srcsetMaxWidth, tolerance: srcsetWidthTolerance,
+			expected: []int64{100, 116, 135, 156, 181, 210, 244, 283, 328, 380, 441, 512, 594, 689, 799, 927,
+				1075, 1247, 1446, 1678, 1946, 2257, 2619, 3038, 3524, 4087, 4741, 5500, 6380, 7401, 8192},
+		},
+		"rounded and capped": {
+			min: 100, max: 200, tolerance: 0.1,
+			expected: []int64{100, 120, 144, 173, 200},
+		},
+		"max reached exactly": {
+			min: 100, max: 144, tolerance: 0.1,
+			expected: []int64{100, 120, 144},
+		},
+		"single width": {
+			min: 500, max: 500, tolerance: 0.08,
+			expected: []int64{500},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := srcsetTargetWidths(test.min, test.max, test.tolerance); !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSrcsetFunction(t *testing.T) {
+	tests := map[string]struct {
+		params   map[string]string
+		options  map[string]attr.Value
+		expected []string
+	}{
+		"width range": {
+			options: map[string]attr.Value{"min_width": types.Int64Value(100), "max_width": types.Int64Value(200), "width_tolerance": types.Float64Value(0.1)},
+			expected: []string{
+				"https://example.imgix.net/image.png?w=100 100w",
+				"https://example.imgix.net/image.png?w=120 120w",
+				"https://example.imgix.net/image.png?w=144 144w",
+				"https://example.imgix.net/image.png?w=173 173w",
+				"https://example.imgix.net/image.png?w=200 200w",
+			},
+		},
+		"explicit widths": {
+			params:  map[string]string{"auto": "format"},
+			options: map[string]attr.Value{"widths": numberTuple(320, 640)},
+			expected: []string{
+				"https://example.imgix.net/image.png?auto=format&w=320 320w",
+				"https://example.imgix.net/image.png?auto=format&w=640 640w",
+			},
+		},
+		"fixed width uses device pixel ratios": {
+			params: map[string]string{"w": "400"},
+			expected: []string{
+				"https://example.imgix.net/image.png?dpr=1&q=75&w=400 1x",
+				"https://example.imgix.net/image.png?dpr=2&q=50&w=400 2x",
+				"https://example.imgix.net/image.png?dpr=3&q=35&w=400 3x",
+				"https://example.imgix.net/image.png?dpr=4&q=23&w=400 4x",
+				"https://example.imgix.net/image.png?dpr=5&q=20&w=400 5x",
+			},
+		},
+		"fixed height with explicit quality": {
+			params:  map[string]string{"h": "300", "q": "90"},
+			options: map[string]attr.Value{"device_pixel_ratios": numberTuple(1, 2)},
+			expected: []string{
+				"https://example.imgix.net/image.png?dpr=1&h=300&q=90 1x",
+				"https://example.imgix.net/image.png?dpr=2&h=300&q=90 2x",
+			},
+		},
+		"variable quality disabled": {
+			params:  map[string]string{"w": "400"},
+			options: map[string]attr.Value{"device_pixel_ratios": numberTuple(1, 2), "disable_variable_quality": types.BoolValue(true)},
+			expected: []string{
+				"https://example.imgix.net/image.png?dpr=1&w=400 1x",
+				"https://example.imgix.net/image.png?dpr=2&w=400 2x",
+			},
+		},
+		"custom variable qualities": {
+			params: map[string]string{"w": "400"},
+			options: map[string]attr.Value{
+				"device_pixel_ratios": numberTuple(2),
+				"variable_qualities":  types.MapValueMust(types.Int64Type, map[string]attr.Value{"2": types.Int64Value(60)}),
+			},
+			expected: []string{
+				"https://example.imgix.net/image.png?dpr=2&q=60&w=400 2x",
+			},
+		},
+		"signed": {
+			params:  map[string]string{"w": "400"},
+			options: map[string]attr.Value{"device_pixel_ratios": numberTuple(1), "token": types.StringValue("FOO123bar")},
+			expected: []string{
+				"https://example.imgix.net/image.png?dpr=1&q=75&w=400&s=" + imgixSignature("FOO123bar", "/image.png", "dpr=1&q=75&w=400") + " 1x",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := runFunction(t, NewSrcsetFunction(), types.StringUnknown(),
+				types.StringValue("example.imgix.net"), types.StringValue("/image.png"),
+				stringMap(t, test.params), srcsetOptions(t, test.options))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			expected := strings.Join(test.expected, ",\n")
+			if !result.Equal(types.StringValue(expected)) {
+				t.Errorf("expected %s, got %s", expected, result)
+			}
+		})
+	}
+}
+
+func TestSrcsetFunctionErrors(t *testing.T) {
+	tests := map[string]struct {
+		options  types.Dynamic
+		expected string
+	}{
+		"not an object": {
+			options:  types.DynamicValue(types.StringValue("wide")),
+			expected: "options must be an object",
+		},
+		"unsupported option": {
+			options:  srcsetOptions(t, map[string]attr.Value{"height": types.Int64Value(1)}),
+			expected: `unsupported option "height"`,
+		},
+		"fractional width": {
+			options:  srcsetOptions(t, map[string]attr.Value{"widths": types.TupleValueMust([]attr.Type{types.Float64Type}, []attr.Value{types.Float64Value(1.5)})}),
+			expected: "widths must be positive integers",
+		},
+		"min above max": {
+			options:  srcsetOptions(t, map[string]attr.Value{"min_width": types.Int64Value(500), "max_width": types.Int64Value(100)}),
+			expected: "min_width must not be greater than max_width",
+		},
+		"tolerance too small": {
+			options:  srcsetOptions(t, map[string]attr.Value{"width_tolerance": types.Float64Value(0)}),
+			expected: "width_tolerance must be a number of at least 0.01",
+		},
+		"negative device pixel ratio": {
+			options:  srcsetOptions(t, map[string]attr.Value{"device_pixel_ratios": types.TupleValueMust([]attr.Type{types.Float64Type}, []attr.Value{types.Float64Value(-1)})}),
+			expected: "device_pixel_ratios must be positive numbers",
+		},
+		"token not a string": {
+			options:  srcsetOptions(t, map[string]attr.Value{"token": types.BoolValue(true)}),
+			expected: "token must be a string",
+		},
+		"unknown options": {
+			options:  types.DynamicUnknown(),
+			expected: "value must be known",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := runFunction(t, NewSrcsetFunction(), types.StringUnknown(),
+				types.StringValue("example.imgix.net"), types.StringValue("/image.png"), stringMap(t, nil), test.options)
+			if err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 3 || err.Text != test.expected {
+				t.Errorf("expected %q on the options argument, got %v", test.expected, err)
+			}
+		})
+	}
+}
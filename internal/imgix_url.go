@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Characters left as-is by JavaScript's encodeURIComponent, which is what the imgix
+// reference libraries use to encode URLs.
+const uriComponentSafe = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_.!~*'()"
+
+// Characters left as-is in paths, encodeURI's reserved characters minus `#?:+`.
+const uriPathSafe = uriComponentSafe + ";,/@&=$"
+
+func percentEncode(s, safe string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if strings.IndexByte(safe, c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// encodeImgixPath encodes a path the way the imgix libraries do. Web proxy paths, which
+// are full URLs, are encoded as a single component.
+func encodeImgixPath(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return "/" + percentEncode(path, uriComponentSafe)
+	}
+	return "/" + percentEncode(path, uriPathSafe)
+}
+
+// isBase64Param reports whether the parameter expects a base64 encoded value, e.g. `txt64`.
+func isBase64Param(key string) bool {
+	return strings.HasSuffix(key, "64")
+}
+
+// encodeBase64Param encodes a value for a base64 parameter, URL safe without padding.
+func encodeBase64Param(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeBase64Param decodes the value of a base64 parameter, accepting padded and
+// standard encodings as well.
+func decodeBase64Param(value string) (string, error) {
+	value = strings.TrimRight(value, "=")
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(value)
+	}
+	return string(decoded), err
+}
+
+// encodeImgixParams encodes the parameters into a query string sorted by key.
+func encodeImgixParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := params[key]
+		if isBase64Param(key) {
+			value = encodeBase64Param(value)
+		} else {
+			value = percentEncode(value, uriComponentSafe)
+		}
+		parts = append(parts, percentEncode(key, uriComponentSafe)+"="+value)
+	}
+	return strings.Join(parts, "&")
+}
+
+// imgixSignature returns the signature of an encoded path and query string.
+func imgixSignature(token, encodedPath, query string) string {
+	base := token + encodedPath
+	if query != "" {
+		base += "?" + query
+	}
+	sum := md5.Sum([]byte(base))
+	return hex.EncodeToString(sum[:])
+}
+
+// trimImgixDomain strips any scheme or trailing slash from the domain.
+func trimImgixDomain(domain string) string {
+	domain = strings.TrimPrefix(strings.TrimPrefix(domain, "https://"), "http://")
+	return strings.TrimSuffix(domain, "/")
+}
+
+// buildImgixURL builds an imgix URL, signing it with `s=` when a token is given.
+func buildImgixURL(domain, path string, params map[string]string, token string) string {
+	encodedPath := encodeImgixPath(path)
+	query := encodeImgixParams(params)
+	if token != "" {
+		signature := "s=" + imgixSignature(token, encodedPath, query)
+		if query == "" {
+			query = signature
+		} else {
+			query += "&" + signature
+		}
+	}
+
+	url := "https://" + trimImgixDomain(domain) + encodedPath
+	if query != "" {
+		url += "?" + query
+	}
+	return url
+}
+
+// ParsedImgixURL is an imgix URL split into its parts.
+type ParsedImgixURL struct {
+	Domain string
+	Path   string
+	Params map[string]string
+	// Signature is the value of `s=`, empty when the URL isn't signed
+	Signature string
+
+	encodedPath string
+	query       string
+}
+
+// parseImgixURL splits an imgix URL into its domain, decoded path and decoded parameters.
+func parseImgixURL(rawURL string) (*ParsedImgixURL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", rawURL)
+	}
+	parsed := &ParsedImgixURL{
+		Domain:      u.Host,
+		Params:      map[string]string{},
+		encodedPath: u.EscapedPath(),
+	}
+	if parsed.Path, err = url.PathUnescape(parsed.encodedPath); err != nil {
+		return nil, err
+	}
+
+	// The signature covers the query as it was written, minus the signature itself
+	unsigned := []string{}
+	for _, part := range strings.Split(u.RawQuery, "&") {
+		if part == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(part, "=")
+		key, err := url.PathUnescape(rawKey)
+		if err != nil {
+			return nil, err
+		}
+		if key == "s" {
+			parsed.Signature = rawValue
+			continue
+		}
+		unsigned = append(unsigned, part)
+
+		var value string
+		if isBase64Param(key) {
+			value, err = decodeBase64Param(rawValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid base64 value for %s: %w", key, err)
+			}
+		} else if value, err = url.PathUnescape(rawValue); err != nil {
+			return nil, err
+		}
+		parsed.Params[key] = value
+	}
+	parsed.query = strings.Join(unsigned, "&")
+	return parsed, nil
+}
+
+// validSignature reports whether the URL is signed with the token.
+func (p *ParsedImgixURL) validSignature(token string) bool {
+	return p.Signature != "" && strings.EqualFold(p.Signature, imgixSignature(token, p.encodedPath, p.query))
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestBuildImgixURLSignature(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		params   map[string]string
+		expected string
+	}{
+		"path": {
+			path:     "/users/1.png",
+			expected: "https://my-social-network.imgix.net/users/1.png?s=6797c24146142d5b40bde3141fd3600c",
+		},
+		"web proxy": {
+			path:     "http://avatars.com/john-smith.png",
+			expected: "https://my-social-network.imgix.net/http%3A%2F%2Favatars.com%2Fjohn-smith.png?s=493a52f008c91416351f8b33d4883135",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := buildImgixURL("my-social-network.imgix.net", test.path, test.params, "FOO123bar")
+			if actual != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+
+			parsed, err := parseImgixURL(actual)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !parsed.validSignature("FOO123bar") {
+				t.Errorf("expected %s to be signed with the token", actual)
+			}
+			if parsed.validSignature("other") {
+				t.Errorf("expected %s not to be signed with another token", actual)
+			}
+		})
+	}
+}
+
+func TestEncodeImgixParamsBase64(t *testing.T) {
+	text := "I cannøt belîév∑ it wors! 😱"
+	expected := "txt64=SSBjYW5uw7h0IGJlbMOuw6l24oiRIGl0IHdvcnMhIPCfmLE"
+	if actual := encodeImgixParams(map[string]string{"txt64": text}); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	for _, encoded := range []string{
+		"SSBjYW5uw7h0IGJlbMOuw6l24oiRIGl0IHdvcnMhIPCfmLE",
+		"SSBjYW5uw7h0IGJlbMOuw6l24oiRIGl0IHdvcnMhIPCfmLE=",
+	} {
+		decoded, err := decodeBase64Param(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded != text {
+			t.Errorf("expected %s to decode to %q, got %q", encoded, text, decoded)
+		}
+	}
+}
+
+func TestEncodeImgixPath(t *testing.T) {
+	tests := map[string]string{
+		"users/1.png":                     "/users/1.png",
+		"/users/1.png":                    "/users/1.png",
+		"/images/hello world.png":         "/images/hello%20world.png",
+		"/images/a+b#c?d:e.png":           "/images/a%2Bb%23c%3Fd%3Ae.png",
+		"/images/&$,;=@.png":              "/images/&$,;=@.png",
+		"/ǂ/ünïcode.png":                  "/%C7%82/%C3%BCn%C3%AFcode.png",
+		"https://example.com/a b.png?x=1": "/https%3A%2F%2Fexample.com%2Fa%20b.png%3Fx%3D1",
+	}
+	for path, expected := range tests {
+		if actual := encodeImgixPath(path); actual != expected {
+			t.Errorf("expected %s to encode to %s, got %s", path, expected, actual)
+		}
+	}
+}
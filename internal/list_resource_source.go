@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// With the list.ListResource implementation
+func NewSourceListResource() list.ListResource {
+	return &SourceListResource{}
+}
+
+// Ensure the implementation satisfies the list.ListResourceWithConfigure interface.
+var _ list.ListResourceWithConfigure = &SourceListResource{}
+
+type SourceListResource struct {
+	client *ImgixClient
+}
+
+type SourceListModel struct {
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *SourceListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source"
+}
+
+func (r *SourceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the sources of the account with `terraform query`, optionally filtered. All filters must match.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the sources with this exact name.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the sources with this deployment type, e.g. `s3`.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the sources that are enabled, or disabled.",
+			},
+		},
+	}
+}
+
+func (r *SourceListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *SourceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		diags.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	data := new(SourceListModel)
+	diags.Append(req.Config.Get(ctx, data)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Let the API filter on the exact name, everything else is filtered here
+	filters := map[string]string{}
+	if !data.Name.IsNull() {
+		filters["name"] = data.Name.ValueString()
+	}
+
+	// Sources are fetched one page at a time as Terraform consumes the results
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		err := r.client.WalkSources(ctx, filters, func(source *ImgixSource) bool {
+			if !sourceMatchesListFilters(source, data) {
+				return true
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = source.Name
+			result.Diagnostics.Append(setSourceIdentity(ctx, result.Identity, types.StringValue(source.ID))...)
+			if req.IncludeResource {
+				// Convert our remote data the same way an import would
+				state := new(SourceModel)
+				result.Diagnostics.Append(convertSourceToSourceModel(ctx, source, new(SourceModel), state)...)
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(result.Resource.Set(ctx, newSourceResourceModel(state, nil))...)
+				}
+			}
+
+			count++
+			return push(result) && (req.Limit <= 0 || count < req.Limit)
+		})
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Failed to list sources", err.Error())
+			push(list.ListResult{Diagnostics: diags})
+		}
+	}
+}
+
+// sourceMatchesListFilters reports whether the source matches every filter that is set.
+func sourceMatchesListFilters(source *ImgixSource, filters *SourceListModel) bool {
+	if !filters.Name.IsNull() && source.Name != filters.Name.ValueString() {
+		return false
+	}
+	if !filters.Type.IsNull() && source.Deployment.Type != filters.Type.ValueString() {
+		return false
+	}
+	if !filters.Enabled.IsNull() && (source.Enabled == nil || *source.Enabled != filters.Enabled.ValueBool()) {
+		return false
+	}
+	return true
+}
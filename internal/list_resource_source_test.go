@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSourceMatchesListFilters(t *testing.T) {
+	enabled := true
+	disabled := false
+	tests := map[string]struct {
+		enabled  *bool
+		filters  SourceListModel
+		expected bool
+	}{
+		"no filters": {
+			filters:  SourceListModel{Name: types.StringNull(), Type: types.StringNull(), Enabled: types.BoolNull()},
+			expected: true,
+		},
+		"name matches": {
+			filters:  SourceListModel{Name: types.StringValue("profile"), Type: types.StringNull(), Enabled: types.BoolNull()},
+			expected: true,
+		},
+		"name mismatch": {
+			filters:  SourceListModel{Name: types.StringValue("avatars"), Type: types.StringNull(), Enabled: types.BoolNull()},
+			expected: false,
+		},
+		"type matches": {
+			filters:  SourceListModel{Name: types.StringNull(), Type: types.StringValue("s3"), Enabled: types.BoolNull()},
+			expected: true,
+		},
+		"type mismatch": {
+			filters:  SourceListModel{Name: types.StringNull(), Type: types.StringValue("gcs"), Enabled: types.BoolNull()},
+			expected: false,
+		},
+		"enabled matches": {
+			enabled:  &enabled,
+			filters:  SourceListModel{Name: types.StringNull(), Type: types.StringNull(), Enabled: types.BoolValue(true)},
+			expected: true,
+		},
+		"enabled mismatch": {
+			enabled:  &disabled,
+			filters:  SourceListModel{Name: types.StringNull(), Type: types.StringNull(), Enabled: types.BoolValue(true)},
+			expected: false,
+		},
+		"enabled not returned": {
+			filters:  SourceListModel{Name: types.StringNull(), Type: types.StringNull(), Enabled: types.BoolValue(false)},
+			expected: false,
+		},
+		"enabled not returned without filter": {
+			filters:  SourceListModel{Name: types.StringNull(), Type: types.StringNull(), Enabled: types.BoolNull()},
+			expected: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &ImgixSource{ID: "abc123", Name: "profile", Enabled: test.enabled}
+			source.Deployment.Type = "s3"
+			if matched := sourceMatchesListFilters(source, &test.filters); matched != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, matched)
+			}
+		})
+	}
+}
+
+func TestSourceListLimit(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if page := r.URL.Query().Get("page[number]"); page != "" {
+			t.Errorf("expected the limit to stop before page %s", page)
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		_, _ = w.Write([]byte(sourcesPage([]string{"a", "b", "c"}, `,"links":{"next":"/api/v1/sources?page%5Bnumber%5D=1"}`)))
+	}))
+	listResource := &SourceListResource{client: client}
+
+	configSchema := new(list.ListResourceSchemaResponse)
+	listResource.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configSchema)
+	config := tfsdk.State{Schema: configSchema.Schema}
+	if diags := config.Set(ctx, &SourceListModel{Name: types.StringNull(), Type: types.StringNull(), Enabled: types.BoolNull()}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	resourceSchema := new(resource.SchemaResponse)
+	new(SourceResource).Schema(ctx, resource.SchemaRequest{}, resourceSchema)
+	identitySchema := new(resource.IdentitySchemaResponse)
+	new(SourceResource).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identitySchema)
+
+	req := list.ListRequest{
+		Config:                 tfsdk.Config{Schema: configSchema.Schema, Raw: config.Raw},
+		Limit:                  2,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}
+	stream := new(list.ListResultsStream)
+	listResource.List(ctx, req, stream)
+
+	var ids []string
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		identity := new(SourceIdentityModel)
+		if diags := result.Identity.Get(ctx, identity); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if identity.ID.ValueString() != result.DisplayName {
+			t.Errorf("expected the identity of %s, got %s", result.DisplayName, identity.ID)
+		}
+		ids = append(ids, identity.ID.ValueString())
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
@@ -5,38 +5,96 @@ package internal
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func UseStateAfterSetModifier() planmodifier.String {
-	return useStateAfterSetModifier{}
+// SecretHashModifier plans the salted hash of the first secret set in the sibling attributes.
+func SecretHashModifier(secretAttributes ...string) planmodifier.String {
+	return secretHashModifier{secretAttributes: secretAttributes}
 }
 
-// useStateAfterSetModifier implements the plan modifier.
-type useStateAfterSetModifier struct{}
+// secretHashModifier implements the plan modifier.
+type secretHashModifier struct {
+	secretAttributes []string
+}
 
 // Description returns a human-readable description of the plan modifier.
-func (m useStateAfterSetModifier) Description(_ context.Context) string {
-	return "Once set, the value of this attribute in state will not change."
+func (m secretHashModifier) Description(_ context.Context) string {
+	return "The value of this attribute changes whenever the hashed secret changes."
 }
 
 // MarkdownDescription returns a markdown description of the plan modifier.
-func (m useStateAfterSetModifier) MarkdownDescription(_ context.Context) string {
-	return "Once set, the value of this attribute in state will not change."
+func (m secretHashModifier) MarkdownDescription(_ context.Context) string {
+	return "The value of this attribute changes whenever the hashed secret changes."
 }
 
-// PlanModifyBool implements the plan modification logic.
-func (m useStateAfterSetModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
-	// Do nothing if there is no state value.
-	if req.StateValue.IsNull() {
+// PlanModifyString implements the plan modification logic.
+func (m secretHashModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Find the configured secret, write-only attributes are only available in the configuration
+	secret := types.StringNull()
+	for _, name := range m.secretAttributes {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName(name), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !value.IsNull() {
+			secret = value
+			break
+		}
+	}
+
+	// Nothing to hash
+	if secret.IsNull() {
+		resp.PlanValue = types.StringNull()
 		return
 	}
 
-	// Do nothing if there is an unknown configuration value, otherwise interpolation gets messed up.
-	if req.ConfigValue.IsUnknown() {
+	// Keep the hash in state if it still matches the secret
+	if !secret.IsUnknown() && !req.StateValue.IsNull() && !req.StateValue.IsUnknown() &&
+		verifySecretHash(secret.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
 		return
 	}
 
-	resp.PlanValue = req.StateValue
+	// The salt is random, so the new hash can only be computed during apply
+	resp.PlanValue = types.StringUnknown()
+}
+
+// hashSecret returns a salted SHA-256 hash of the secret in the form `<salt>$<hash>`.
+func hashSecret(secret string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return base64.RawStdEncoding.EncodeToString(salt) + "$" + saltedSecretDigest(salt, secret), nil
+}
+
+// verifySecretHash reports whether the hash was computed by hashSecret for the secret.
+func verifySecretHash(secret, hash string) bool {
+	encodedSalt, digest, ok := strings.Cut(hash, "$")
+	if !ok {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(digest), []byte(saltedSecretDigest(salt, secret))) == 1
+}
+
+func saltedSecretDigest(salt []byte, secret string) string {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(secret))
+	return hex.EncodeToString(h.Sum(nil))
 }
@@ -5,6 +5,9 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,6 +25,15 @@ func NewProvider(version string) func() provider.Provider {
 // Ensure the implementation satisfies the provider.Provider interface.
 var _ provider.Provider = &ImgixyzProvider{}
 
+// Ensure the implementation satisfies the provider.ProviderWithFunctions interface.
+var _ provider.ProviderWithFunctions = &ImgixyzProvider{}
+
+// Ensure the implementation satisfies the provider.ProviderWithEphemeralResources interface.
+var _ provider.ProviderWithEphemeralResources = &ImgixyzProvider{}
+
+// Ensure the implementation satisfies the provider.ProviderWithListResources interface.
+var _ provider.ProviderWithListResources = &ImgixyzProvider{}
+
 type ImgixyzProvider struct {
 	// Version is an example field that can be set with an actual provider
 	// version on release, "dev" when the provider is built and ran locally,
@@ -84,12 +96,18 @@ func (p *ImgixyzProvider) Configure(ctx context.Context, req provider.ConfigureR
 	client := NewImgixClient(token, data.UpsertByName.ValueBool())
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ListResourceData = client
 }
 
 // DataSources satisfies the provider.Provider interface for ImgixyzProvider.
 func (p *ImgixyzProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSourceDataSource,
+		NewReportDataDataSource,
+		NewSourcesDataSource,
+		NewAssetDataSource,
+		NewAssetsDataSource,
 	}
 }
 
@@ -97,5 +115,35 @@ func (p *ImgixyzProvider) DataSources(ctx context.Context) []func() datasource.D
 func (p *ImgixyzProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSourceResource,
+		NewPurgeResource,
+		NewReportResource,
+		NewSourceSecureTokenResource,
+		NewAssetMetadataResource,
+		NewAssetUploadResource,
+		NewAssetRefreshResource,
+	}
+}
+
+// EphemeralResources satisfies the provider.ProviderWithEphemeralResources interface for ImgixyzProvider.
+func (p *ImgixyzProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSignedURLEphemeralResource,
+	}
+}
+
+// ListResources satisfies the provider.ProviderWithListResources interface for ImgixyzProvider.
+func (p *ImgixyzProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewSourceListResource,
+	}
+}
+
+// Functions satisfies the provider.ProviderWithFunctions interface for ImgixyzProvider.
+func (p *ImgixyzProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewSignURLFunction,
+		NewBuildURLFunction,
+		NewSrcsetFunction,
+		NewParseURLFunction,
 	}
 }
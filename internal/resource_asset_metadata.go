@@ -0,0 +1,440 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Confidence given to tags added by this provider, the same as tags added by hand
+const manualTagConfidence = 1
+
+// With the resource.Resource implementation
+func NewAssetMetadataResource() resource.Resource {
+	return &AssetMetadataResource{}
+}
+
+// Ensure the implementation satisfies the resource.ResourceWithConfigure interface.
+var _ resource.ResourceWithConfigure = &AssetMetadataResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithImportState interface.
+var _ resource.ResourceWithImportState = &AssetMetadataResource{}
+
+type AssetMetadataResource struct {
+	client *ImgixClient
+}
+
+type AssetMetadataModel struct {
+	ID           types.String `tfsdk:"id"`
+	SourceID     types.String `tfsdk:"source_id"`
+	OriginPath   types.String `tfsdk:"origin_path"`
+	Description  types.String `tfsdk:"description"`
+	Tags         types.Set    `tfsdk:"tags"`
+	Categories   types.Set    `tfsdk:"categories"`
+	CustomFields types.Map    `tfsdk:"custom_fields"`
+}
+
+// ownedAssetMetadata holds the metadata managed by the resource.
+type ownedAssetMetadata struct {
+	Description  *string
+	Tags         []string
+	Categories   []string
+	CustomFields map[string]string
+}
+
+func (r *AssetMetadataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_metadata"
+}
+
+func (r AssetMetadataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the metadata of an asset in the Asset Manager. Only the tags, categories and custom fields " +
+			"set here are managed, others added from the dashboard are left as-is. Destroying this resource removes the " +
+			"managed metadata from the asset.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the source of the asset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"origin_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path of the asset in the origin, e.g. `/images/logo.png`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the asset. Left as-is when never set, cleared when removed.",
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Tags the asset must have.",
+			},
+			"categories": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Categories the asset must be in.",
+			},
+			"custom_fields": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Custom fields the asset must have.",
+			},
+		},
+	}
+}
+
+func (r *AssetMetadataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r AssetMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan data into the model
+	data := new(AssetMetadataModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, diags := r.patchAssetMetadata(ctx, data, nil, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(asset.ID)
+
+	// Set our state
+	tflog.Trace(ctx, "updated the metadata of an asset", map[string]interface{}{"id": asset.ID})
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *AssetMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform state data into the model
+	data := new(AssetMetadataModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch our remote data
+	asset, err := r.client.GetAsset(ctx, data.SourceID.ValueString(), data.OriginPath.ValueString())
+	var notFound *AssetNotFoundError
+	if errors.As(err, &notFound) {
+		tflog.Warn(ctx, "asset not found, removing it from the state", map[string]interface{}{"origin_path": data.OriginPath.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get asset", err.Error())
+		return
+	}
+	data.ID = types.StringValue(asset.ID)
+
+	// Only refresh the metadata we own, so changes to it show up as drift
+	if !data.Description.IsNull() {
+		data.Description = types.StringPointerValue(asset.Description)
+	}
+	owned, diags := ownedMetadata(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.Tags.IsNull() {
+		tags := []string{}
+		for _, tag := range owned.Tags {
+			if _, ok := asset.Tags[tag]; ok {
+				tags = append(tags, tag)
+			}
+		}
+		data.Tags, diags = types.SetValueFrom(ctx, types.StringType, tags)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !data.Categories.IsNull() {
+		categories := []string{}
+		for _, category := range owned.Categories {
+			if containsString(asset.Categories, category) {
+				categories = append(categories, category)
+			}
+		}
+		data.Categories, diags = types.SetValueFrom(ctx, types.StringType, categories)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !data.CustomFields.IsNull() {
+		remoteFields, err := stringifyValues(asset.CustomFields)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to encode custom_fields", err.Error())
+			return
+		}
+		fields := map[string]string{}
+		for key := range owned.CustomFields {
+			if value, ok := remoteFields[key]; ok {
+				fields[key] = value
+			}
+		}
+		data.CustomFields, diags = types.MapValueFrom(ctx, types.StringType, fields)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set our state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r AssetMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan and state data into the models
+	data := new(AssetMetadataModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	state := new(AssetMetadataModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, diags := r.patchAssetMetadata(ctx, data, state, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(asset.ID)
+
+	// Set our state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r AssetMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform state data into the model
+	state := new(AssetMetadataModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove everything we own from the asset, if it's still there
+	_, diags := r.patchAssetMetadata(ctx, state, state, nil)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AssetMetadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import as <source_id>/<origin_path>, the origin path starts with a slash
+	sourceID, originPath, ok := strings.Cut(req.ID, "/")
+	if !ok || sourceID == "" || originPath == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID like <source_id>/<origin_path>, got: %q.", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), sourceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("origin_path"), "/"+strings.TrimPrefix(originPath, "/"))...)
+}
+
+// patchAssetMetadata applies the metadata we want to own to the asset, and removes the
+// metadata we owned before but don't anymore. Metadata owned by nobody else is left as-is.
+// The asset is fetched right before patching it, and other resources of this provider
+// wait for the patch, so the merge doesn't undo their changes. Nothing is done when
+// there's nothing wanted and the asset is gone.
+func (r AssetMetadataResource) patchAssetMetadata(ctx context.Context, target, prior, wanted *AssetMetadataModel) (*ImgixAsset, diag.Diagnostics) {
+	sourceID, originPath := target.SourceID.ValueString(), target.OriginPath.ValueString()
+	unlock := r.client.lockAsset(sourceID, originPath)
+	defer unlock()
+
+	var diags diag.Diagnostics
+	before, d := ownedMetadata(ctx, prior)
+	diags.Append(d...)
+	after, d := ownedMetadata(ctx, wanted)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	asset, err := r.client.GetAsset(ctx, sourceID, originPath)
+	var notFound *AssetNotFoundError
+	if errors.As(err, &notFound) && wanted == nil {
+		return nil, diags
+	}
+	if errors.As(err, &notFound) {
+		diags.AddError("Asset Not Found", err.Error())
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddError("Failed to get asset", err.Error())
+		return nil, diags
+	}
+	asset.SourceID = sourceID
+	asset.OriginPath = originPath
+
+	attributes := map[string]interface{}{}
+	if after.Description != nil {
+		if asset.Description == nil || *asset.Description != *after.Description {
+			attributes["description"] = *after.Description
+		}
+	} else if before.Description != nil {
+		attributes["description"] = nil
+	}
+
+	tags := make(map[string]interface{}, len(asset.Tags))
+	for tag, confidence := range asset.Tags {
+		tags[tag] = confidence
+	}
+	for _, tag := range before.Tags {
+		delete(tags, tag)
+	}
+	for _, tag := range after.Tags {
+		if confidence, ok := asset.Tags[tag]; ok {
+			tags[tag] = confidence
+		} else {
+			tags[tag] = manualTagConfidence
+		}
+	}
+	if len(tags) != len(asset.Tags) || !reflect.DeepEqual(tags, asset.Tags) {
+		attributes["tags"] = tags
+	}
+
+	categories := []string{}
+	for _, category := range asset.Categories {
+		if !containsString(before.Categories, category) || containsString(after.Categories, category) {
+			categories = append(categories, category)
+		}
+	}
+	for _, category := range after.Categories {
+		if !containsString(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+	if !reflect.DeepEqual(categories, append([]string{}, asset.Categories...)) {
+		attributes["categories"] = categories
+	}
+
+	fields := make(map[string]interface{}, len(asset.CustomFields))
+	for key, value := range asset.CustomFields {
+		fields[key] = value
+	}
+	for key := range before.CustomFields {
+		delete(fields, key)
+	}
+	for key, value := range after.CustomFields {
+		fields[key] = value
+	}
+	if len(fields) != len(asset.CustomFields) || !reflect.DeepEqual(fields, asset.CustomFields) {
+		attributes["custom_fields"] = fields
+	}
+
+	if len(attributes) == 0 {
+		return asset, diags
+	}
+	updated, err := r.client.UpdateAsset(ctx, asset, attributes)
+	if err != nil {
+		diags.AddError(
+			"Unable to Update Asset Metadata",
+			"An unexpected error occurred while updating the asset metadata. "+
+				"Please report this issue to the provider developers.\n\n"+
+				"Client Error: "+err.Error(),
+		)
+		return nil, diags
+	}
+	if updated.ID == "" {
+		updated.ID = asset.ID
+	}
+	return updated, diags
+}
+
+// ownedMetadata returns the metadata owned according to the model, nothing when it's nil.
+func ownedMetadata(ctx context.Context, data *AssetMetadataModel) (*ownedAssetMetadata, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	owned := &ownedAssetMetadata{CustomFields: map[string]string{}}
+	if data == nil {
+		return owned, diags
+	}
+	owned.Description = data.Description.ValueStringPointer()
+	if !data.Tags.IsNull() {
+		diags.Append(data.Tags.ElementsAs(ctx, &owned.Tags, false)...)
+	}
+	if !data.Categories.IsNull() {
+		diags.Append(data.Categories.ElementsAs(ctx, &owned.Categories, false)...)
+	}
+	if !data.CustomFields.IsNull() {
+		diags.Append(data.CustomFields.ElementsAs(ctx, &owned.CustomFields, false)...)
+	}
+	return owned, diags
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeAssetServer serves a single asset whose tags are replaced by each PATCH, like the
+// Asset Manager does. GETs are slow so concurrent read-modify-writes would overlap.
+type fakeAssetServer struct {
+	mu       sync.Mutex
+	tags     map[string]interface{}
+	requests []string
+}
+
+func (s *fakeAssetServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method)
+	s.mu.Unlock()
+	if r.Method == http.MethodGet {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if r.Method == http.MethodPatch {
+		var document struct {
+			Data struct {
+				Attributes struct {
+					Tags map[string]interface{} `json:"tags"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&document); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.tags = document.Data.Attributes.Tags
+		s.mu.Unlock()
+	}
+	s.mu.Lock()
+	tags, _ := json.Marshal(s.tags)
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	_, _ = w.Write([]byte(`{"data":{"type":"assets","id":"abc123/users/1.png","attributes":{"tags":` + string(tags) + `}}}`))
+}
+
+func (s *fakeAssetServer) tagNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := []string{}
+	for tag := range s.tags {
+		names = append(names, tag)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// assetMetadataModel returns the metadata of the fake asset, owning only the tags.
+func assetMetadataModel(tags ...string) *AssetMetadataModel {
+	values := make([]attr.Value, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, types.StringValue(tag))
+	}
+	return &AssetMetadataModel{
+		SourceID:     types.StringValue("abc123"),
+		OriginPath:   types.StringValue("/users/1.png"),
+		Description:  types.StringNull(),
+		Tags:         types.SetValueMust(types.StringType, values),
+		Categories:   types.SetNull(types.StringType),
+		CustomFields: types.MapNull(types.StringType),
+	}
+}
+
+func TestPatchAssetMetadataKeepsOtherTags(t *testing.T) {
+	server := &fakeAssetServer{tags: map[string]interface{}{"external": 0.5, "old": 1}}
+	r := AssetMetadataResource{client: newTestClient(t, server)}
+
+	if _, diags := r.patchAssetMetadata(context.Background(), assetMetadataModel(), assetMetadataModel("old"), assetMetadataModel("new")); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if expected := []string{"external", "new"}; !reflect.DeepEqual(server.tagNames(), expected) {
+		t.Errorf("expected the tags %v, got %v", expected, server.tagNames())
+	}
+	if expected := []string{"GET", "PATCH"}; !reflect.DeepEqual(server.requests, expected) {
+		t.Errorf("expected the asset to be fetched right before the patch, got %v", server.requests)
+	}
+}
+
+func TestPatchAssetMetadataConcurrent(t *testing.T) {
+	server := &fakeAssetServer{tags: map[string]interface{}{}}
+	r := AssetMetadataResource{client: newTestClient(t, server)}
+
+	var wg sync.WaitGroup
+	for _, tag := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func(tag string) {
+			defer wg.Done()
+			if _, diags := r.patchAssetMetadata(context.Background(), assetMetadataModel(), nil, assetMetadataModel(tag)); diags.HasError() {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		}(tag)
+	}
+	wg.Wait()
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(server.tagNames(), expected) {
+		t.Errorf("expected the tags %v, got %v", expected, server.tagNames())
+	}
+}
+
+func TestPatchAssetMetadataMissingAsset(t *testing.T) {
+	r := AssetMetadataResource{client: newTestClient(t, http.NotFoundHandler())}
+
+	if _, diags := r.patchAssetMetadata(context.Background(), assetMetadataModel(), assetMetadataModel("old"), nil); diags.HasError() {
+		t.Errorf("expected removing metadata from a missing asset to do nothing, got %v", diags)
+	}
+	if _, diags := r.patchAssetMetadata(context.Background(), assetMetadataModel(), nil, assetMetadataModel("new")); !diags.HasError() || diags.Errors()[0].Summary() != "Asset Not Found" {
+		t.Errorf("expected an Asset Not Found error, got %v", diags)
+	}
+}
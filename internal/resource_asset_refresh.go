@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// With the resource.Resource implementation
+func NewAssetRefreshResource() resource.Resource {
+	return &AssetRefreshResource{}
+}
+
+// Ensure the implementation satisfies the resource.ResourceWithConfigure interface.
+var _ resource.ResourceWithConfigure = &AssetRefreshResource{}
+
+type AssetRefreshResource struct {
+	client *ImgixClient
+}
+
+type AssetRefreshModel struct {
+	ID          types.String `tfsdk:"id"`
+	SourceID    types.String `tfsdk:"source_id"`
+	OriginPaths types.List   `tfsdk:"origin_paths"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	RefreshedAt types.String `tfsdk:"refreshed_at"`
+}
+
+func (r *AssetRefreshResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_refresh"
+}
+
+func (r AssetRefreshResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Refreshes the Asset Manager metadata of assets from their origin on create and whenever any " +
+			"of the arguments change, e.g. after files were overwritten in the bucket. Destroying this resource doesn't do anything.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the source of the assets.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"origin_paths": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Origin paths of the assets to refresh, e.g. `/images/hero.png`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that refresh the assets again when changed, e.g. the ETags of the files.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"refreshed_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the assets were refreshed, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AssetRefreshResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r AssetRefreshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan data into the model
+	data := new(AssetRefreshModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var originPaths []string
+	resp.Diagnostics.Append(data.OriginPaths.ElementsAs(ctx, &originPaths, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Refresh every asset, reporting each failure rather than stopping at the first one
+	refreshed := 0
+	for i, originPath := range originPaths {
+		tflog.Debug(ctx, "Refreshing asset", map[string]interface{}{"origin_path": originPath})
+		if err := r.client.RefreshAsset(ctx, data.SourceID.ValueString(), originPath); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("origin_paths").AtListIndex(i),
+				"Unable to Refresh Asset",
+				"An unexpected error occurred while refreshing "+originPath+". "+
+					"Please report this issue to the provider developers.\n\n"+
+					"Client Error: "+err.Error(),
+			)
+			continue
+		}
+		refreshed++
+	}
+	// Keep the state when only some paths failed, Terraform taints it so the next apply retries them
+	if refreshed == 0 {
+		return
+	}
+
+	now := time.Now()
+	data.ID = types.StringValue(strconv.FormatInt(now.UnixNano(), 10))
+	data.RefreshedAt = types.StringValue(now.UTC().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *AssetRefreshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Refreshes can't be read back from Imgix, so keep our state as-is
+}
+
+func (r AssetRefreshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument requires replacement, so there's nothing to refresh here
+	data := new(AssetRefreshModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r AssetRefreshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Refreshes can't be undone, removing the resource from state is enough
+}
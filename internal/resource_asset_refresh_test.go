@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAssetRefreshCreateFailures(t *testing.T) {
+	tests := map[string]struct {
+		originPaths []string
+		failing     []int
+		saved       bool
+	}{
+		"all refreshed": {
+			originPaths: []string{"/a.png", "/b.png"},
+			saved:       true,
+		},
+		"some failing": {
+			originPaths: []string{"/missing-a.png", "/b.png", "/missing-c.png"},
+			failing:     []int{0, 2},
+			saved:       true,
+		},
+		"all failing": {
+			originPaths: []string{"/missing-a.png", "/missing-b.png"},
+			failing:     []int{0, 1},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/refresh") {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if strings.Contains(r.URL.Path, "missing") {
+					http.Error(w, `{"errors":[{"status":"500"}]}`, http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			schemaResp := new(resource.SchemaResponse)
+			new(AssetRefreshResource).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			originPaths, diags := types.ListValueFrom(ctx, types.StringType, test.originPaths)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			plan := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := plan.Set(ctx, &AssetRefreshModel{
+				ID:          types.StringUnknown(),
+				SourceID:    types.StringValue("abc123"),
+				OriginPaths: originPaths,
+				Triggers:    types.MapNull(types.StringType),
+				RefreshedAt: types.StringUnknown(),
+			}); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan.Raw}}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			AssetRefreshResource{client: client}.Create(ctx, req, resp)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != len(test.failing) {
+				t.Fatalf("expected %d errors, got %v", len(test.failing), resp.Diagnostics)
+			}
+			for i, index := range test.failing {
+				withPath, ok := errs[i].(diag.DiagnosticWithPath)
+				if expected := path.Root("origin_paths").AtListIndex(index); !ok || !withPath.Path().Equal(expected) {
+					t.Errorf("expected an error on %s, got %v", expected, errs[i])
+				}
+			}
+			if saved := !resp.State.Raw.IsNull(); saved != test.saved {
+				t.Errorf("expected the state to be saved: %t, got %t", test.saved, saved)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// With the resource.Resource implementation
+func NewAssetUploadResource() resource.Resource {
+	return &AssetUploadResource{}
+}
+
+// Ensure the implementation satisfies the resource.ResourceWithConfigure interface.
+var _ resource.ResourceWithConfigure = &AssetUploadResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithModifyPlan interface.
+var _ resource.ResourceWithModifyPlan = &AssetUploadResource{}
+
+// How long an uploaded file may be missing from the Asset Manager before it's considered deleted
+const assetIndexingDelay = time.Hour
+
+// Key of the private state recording when the file was last uploaded
+const uploadedAtPrivateKey = "uploaded_at"
+
+type AssetUploadResource struct {
+	client *ImgixClient
+}
+
+type AssetUploadModel struct {
+	ID          types.String `tfsdk:"id"`
+	SourceID    types.String `tfsdk:"source_id"`
+	OriginPath  types.String `tfsdk:"origin_path"`
+	File        types.String `tfsdk:"file"`
+	ContentHash types.String `tfsdk:"content_hash"`
+	ContentType types.String `tfsdk:"content_type"`
+}
+
+func (r *AssetUploadResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_upload"
+}
+
+func (r AssetUploadResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a local file to the origin path of a source, and uploads it again whenever its content changes. " +
+			"The source must allow uploads. Destroying this resource leaves the file in the origin.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the source to upload to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"origin_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to upload the file to in the origin, e.g. `/images/hero.png`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path of the local file to upload.",
+			},
+			"content_hash": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Hash of the content of the file, the file is uploaded again when it changes. " +
+					"Defaults to the hex encoded SHA-256 of the file, e.g. `filesha256(\"hero.png\")`.",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "MIME type of the file. Defaults to the type of its extension.",
+			},
+		},
+	}
+}
+
+func (r *AssetUploadResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r AssetUploadResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := new(AssetUploadModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	config := new(AssetUploadModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Hash the file on every plan, so changes to its content show up even though the
+	// configuration didn't change
+	if !plan.File.IsUnknown() {
+		if config.ContentHash.IsNull() {
+			hash, err := hashFile(plan.File.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("file"), "Unable to Read File", err.Error())
+				return
+			}
+			plan.ContentHash = types.StringValue(hash)
+		}
+		if config.ContentType.IsNull() {
+			plan.ContentType = types.StringValue(detectContentType(plan.File.ValueString()))
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	}
+
+	// Fail early rather than halfway through the apply when the file will be uploaded
+	if !req.State.Raw.IsNull() {
+		state := new(AssetUploadModel)
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() || (plan.ContentHash.Equal(state.ContentHash) && plan.ContentType.Equal(state.ContentType)) {
+			return
+		}
+	}
+	if plan.SourceID.IsUnknown() || r.client == nil {
+		return
+	}
+	source, err := r.client.GetSourceByID(ctx, plan.SourceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_id"), "Failed to get source", err.Error())
+		return
+	}
+	if !source.Deployment.AllowsUpload {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_id"),
+			"Uploads Not Allowed",
+			fmt.Sprintf("The source %s doesn't allow uploads, enable them on its deployment first.", source.ID),
+		)
+	}
+}
+
+func (r AssetUploadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan data into the model
+	data := new(AssetUploadModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.uploadFile(ctx, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upload File",
+			"An unexpected error occurred while uploading the file. "+
+				"Please report this issue to the provider developers.\n\n"+
+				"Client Error: "+err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(data.SourceID.ValueString() + "/" + strings.TrimPrefix(data.OriginPath.ValueString(), "/"))
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, uploadedAtPrivateKey, uploadedAt(time.Now()))...)
+
+	// Set our state
+	tflog.Trace(ctx, "uploaded a file", map[string]interface{}{"id": data.ID.ValueString()})
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *AssetUploadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform state data into the model
+	data := new(AssetUploadModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Forget the file once the Asset Manager has had time to index it and still doesn't
+	// know it, so it's uploaded again
+	_, err := r.client.GetAsset(ctx, data.SourceID.ValueString(), data.OriginPath.ValueString())
+	var notFound *AssetNotFoundError
+	if errors.As(err, &notFound) {
+		private, diags := req.Private.GetKey(ctx, uploadedAtPrivateKey)
+		resp.Diagnostics.Append(diags...)
+		if recentlyUploaded(private, time.Now()) {
+			tflog.Debug(ctx, "uploaded file not indexed yet, keeping it in the state", map[string]interface{}{"id": data.ID.ValueString()})
+			resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+			return
+		}
+		tflog.Warn(ctx, "uploaded file not found, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get asset", err.Error())
+		return
+	}
+
+	// Set our state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r AssetUploadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan and state data into the models
+	data := new(AssetUploadModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	state := new(AssetUploadModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Moving the file around locally doesn't need a new upload
+	if !data.ContentHash.Equal(state.ContentHash) || !data.ContentType.Equal(state.ContentType) {
+		if err := r.uploadFile(ctx, data); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Upload File",
+				"An unexpected error occurred while uploading the file. "+
+					"Please report this issue to the provider developers.\n\n"+
+					"Client Error: "+err.Error(),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, uploadedAtPrivateKey, uploadedAt(time.Now()))...)
+	}
+
+	// Set our state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r AssetUploadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The Management API can't delete files from the origin
+	tflog.Trace(ctx, "removed an uploaded file from the state, the file is left in the origin")
+}
+
+// uploadFile uploads the file once it's checked to still match the planned hash.
+func (r AssetUploadResource) uploadFile(ctx context.Context, data *AssetUploadModel) error {
+	file, err := os.Open(data.File.ValueString())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Only SHA-256 hashes can be checked, any other hash is trusted as-is
+	planned := data.ContentHash.ValueString()
+	if _, err := hex.DecodeString(planned); err == nil && len(planned) == sha256.Size*2 {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			return err
+		}
+		if current := hex.EncodeToString(hash.Sum(nil)); current != strings.ToLower(planned) {
+			return fmt.Errorf("the file %s changed since the plan was made", data.File.ValueString())
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return r.client.UploadAsset(ctx, data.SourceID.ValueString(), data.OriginPath.ValueString(), data.ContentType.ValueString(), file, info.Size())
+}
+
+// uploadedAt returns the private state recording when the file was uploaded.
+func uploadedAt(t time.Time) []byte {
+	value, _ := json.Marshal(map[string]string{"uploaded_at": t.UTC().Format(time.RFC3339)})
+	return value
+}
+
+// recentlyUploaded reports whether the file was uploaded by this resource so recently
+// that the Asset Manager may not have indexed it yet.
+func recentlyUploaded(private []byte, now time.Time) bool {
+	var value struct {
+		UploadedAt time.Time `json:"uploaded_at"`
+	}
+	if len(private) == 0 || json.Unmarshal(private, &value) != nil {
+		return false
+	}
+	return now.Sub(value.UploadedAt) < assetIndexingDelay
+}
+
+// hashFile returns the hex encoded SHA-256 of the file, the same as `filesha256`.
+func hashFile(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// detectContentType guesses the MIME type of the file from its extension, then its content.
+func detectContentType(name string) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer file.Close()
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	return http.DetectContentType(head[:n])
+}
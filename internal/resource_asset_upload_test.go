@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUploadFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "hero.png")
+	if err := os.WriteFile(name, []byte("hero"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := hashFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		contentHash string
+		expected    string
+		uploaded    bool
+	}{
+		"matching hash":  {contentHash: hash, uploaded: true},
+		"uppercase hash": {contentHash: strings.ToUpper(hash), uploaded: true},
+		"other hash":     {contentHash: "v1", uploaded: true},
+		"changed file":   {contentHash: strings.Repeat("0", 64), expected: "changed since the plan was made"},
+	}
+	for caseName, test := range tests {
+		t.Run(caseName, func(t *testing.T) {
+			var uploaded string
+			r := AssetUploadResource{client: newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				uploaded = string(body)
+			}))}
+			data := &AssetUploadModel{
+				SourceID:    types.StringValue("abc123"),
+				OriginPath:  types.StringValue("/hero.png"),
+				File:        types.StringValue(name),
+				ContentHash: types.StringValue(test.contentHash),
+				ContentType: types.StringValue("image/png"),
+			}
+
+			err := r.uploadFile(context.Background(), data)
+			if test.expected != "" && (err == nil || !strings.Contains(err.Error(), test.expected)) {
+				t.Errorf("expected an error containing %q, got %v", test.expected, err)
+			}
+			if test.expected == "" && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if test.uploaded && uploaded != "hero" {
+				t.Errorf("expected the whole file to be uploaded, got %q", uploaded)
+			}
+			if !test.uploaded && uploaded != "" {
+				t.Errorf("expected nothing to be uploaded, got %q", uploaded)
+			}
+		})
+	}
+}
+
+func TestRecentlyUploaded(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		private  []byte
+		expected bool
+	}{
+		"just uploaded":    {private: uploadedAt(now.Add(-time.Minute)), expected: true},
+		"indexing done":    {private: uploadedAt(now.Add(-2 * time.Hour)), expected: false},
+		"never recorded":   {private: nil, expected: false},
+		"invalid recorded": {private: []byte(`{"uploaded_at":"yesterday"}`), expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := recentlyUploaded(test.private, now); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
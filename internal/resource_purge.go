@@ -0,0 +1,219 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// With the resource.Resource implementation
+func NewPurgeResource() resource.Resource {
+	return &PurgeResource{}
+}
+
+// Ensure the implementation satisfies the resource.ResourceWithConfigure interface.
+var _ resource.ResourceWithConfigure = &PurgeResource{}
+
+type PurgeResource struct {
+	client *ImgixClient
+}
+
+type PurgeModel struct {
+	ID       types.String `tfsdk:"id"`
+	SourceID types.String `tfsdk:"source_id"`
+	URLs     types.List   `tfsdk:"urls"`
+	SubImage types.Bool   `tfsdk:"sub_image"`
+	Triggers types.Map    `tfsdk:"triggers"`
+	Purges   types.List   `tfsdk:"purges"`
+}
+
+type PurgeResultModel struct {
+	ID       types.String `tfsdk:"id"`
+	URL      types.String `tfsdk:"url"`
+	PurgedAt types.String `tfsdk:"purged_at"`
+}
+
+var purgeResultObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":        types.StringType,
+		"url":       types.StringType,
+		"purged_at": types.StringType,
+	},
+}
+
+func (r *PurgeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_purge"
+}
+
+func (r PurgeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Purges URLs from the Imgix cache on create and whenever any of the arguments change. " +
+			"Destroying this resource doesn't do anything.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the source the URLs belong to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"urls": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Full URLs to purge, e.g. `https://example.imgix.net/image.jpg`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"sub_image": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Also purge images that use the URLs as a watermark, blend or other sub-image.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that purge the URLs again when changed.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"purges": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The purges that were issued, one per URL.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":        schema.StringAttribute{Computed: true},
+						"url":       schema.StringAttribute{Computed: true},
+						"purged_at": schema.StringAttribute{Computed: true},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PurgeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r PurgeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan data into the model
+	data := new(PurgeModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var urls []string
+	resp.Diagnostics.Append(data.URLs.ElementsAs(ctx, &urls, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Purge every URL
+	purges, diags := purgeURLs(ctx, r.client, data.SourceID.ValueString(), urls, data.SubImage.ValueBool())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Convert our purges into our terraform model
+	data.ID = types.StringValue(strconv.FormatInt(time.Now().UnixNano(), 10))
+	data.Purges, diags = types.ListValueFrom(ctx, purgeResultObjectType, purges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// purgeURLs purges each URL from the Imgix cache, stopping at the first failure.
+func purgeURLs(ctx context.Context, client *ImgixClient, sourceID string, urls []string, subImage bool) ([]PurgeResultModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	purges := make([]PurgeResultModel, 0, len(urls))
+	for _, url := range urls {
+		tflog.Debug(ctx, "Purging URL", map[string]interface{}{"url": url})
+		purge, err := client.CreatePurge(ctx, &ImgixPurge{URL: url, SourceID: sourceID, SubImage: subImage})
+		if err != nil {
+			diags.AddError(
+				"Unable to Purge URL",
+				"An unexpected error occurred while purging "+url+". "+
+					"Please report this issue to the provider developers.\n\n"+
+					"Client Error: "+err.Error(),
+			)
+			return purges, diags
+		}
+		purges = append(purges, PurgeResultModel{
+			ID:       types.StringValue(purge.ID),
+			URL:      types.StringValue(url),
+			PurgedAt: types.StringValue(time.Now().UTC().Format(time.RFC3339)),
+		})
+	}
+	return purges, diags
+}
+
+func (r *PurgeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Purges can't be read back from Imgix, so keep our state as-is
+}
+
+func (r PurgeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument requires replacement, so there's nothing to purge here
+	data := new(PurgeModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r PurgeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Purges can't be undone, removing the resource from state is enough
+}
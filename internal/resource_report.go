@@ -0,0 +1,294 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	reportDateLayout   = "2006-01-02"
+	reportPollInterval = 10 * time.Second
+	reportPollTimeout  = 15 * time.Minute
+)
+
+// With the resource.Resource implementation
+func NewReportResource() resource.Resource {
+	return &ReportResource{}
+}
+
+// Ensure the implementation satisfies the resource.ResourceWithConfigure interface.
+var _ resource.ResourceWithConfigure = &ReportResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithValidateConfig interface.
+var _ resource.ResourceWithValidateConfig = &ReportResource{}
+
+type ReportResource struct {
+	client *ImgixClient
+}
+
+type ReportModel struct {
+	ID         types.String `tfsdk:"id"`
+	ReportType types.String `tfsdk:"report_type"`
+	StartDate  types.String `tfsdk:"start_date"`
+	EndDate    types.String `tfsdk:"end_date"`
+	Status     types.String `tfsdk:"status"`
+	URL        types.String `tfsdk:"url"`
+}
+
+func (r *ReportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report"
+}
+
+func (r ReportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Requests an Imgix analytics report and waits until it's ready to download. " +
+			"Changing any argument requests a new report, destroying this resource doesn't do anything.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"report_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Type of report to request, see the [Imgix Management API](https://docs.imgix.com/apis/management) for the available types.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "First day of the report in `YYYY-MM-DD` format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"end_date": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Last day of the report in `YYYY-MM-DD` format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Either `pending` or `completed`. Reports still pending after the apply are picked up by a later refresh.",
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Download URL of the report once completed.",
+			},
+		},
+	}
+}
+
+func (r *ReportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// ValidateConfig satisfies the resource.ResourceWithValidateConfig interface for ReportResource.
+func (r ReportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data := new(ReportModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	start, startOK := parseReportDate(data.StartDate, "start_date", resp)
+	end, endOK := parseReportDate(data.EndDate, "end_date", resp)
+	if startOK && endOK && end.Before(start) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid End Date",
+			fmt.Sprintf("The end_date %s is before the start_date %s.", data.EndDate.ValueString(), data.StartDate.ValueString()),
+		)
+	}
+}
+
+// parseReportDate parses a date of the configuration, reporting whether it's known and valid.
+func parseReportDate(value types.String, attribute string, resp *resource.ValidateConfigResponse) (time.Time, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(reportDateLayout, value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Date",
+			fmt.Sprintf("The %s must be a date in YYYY-MM-DD format, got %q.", attribute, value.ValueString()),
+		)
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func (r ReportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan data into the model
+	data := new(ReportModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Convert our dates into the timestamps imgix expects, the end date is inclusive
+	start, err := time.Parse(reportDateLayout, data.StartDate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("start_date"), "Invalid Start Date", err.Error())
+	}
+	end, err := time.Parse(reportDateLayout, data.EndDate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("end_date"), "Invalid End Date", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call out to our api and request the report
+	report, err := r.client.CreateReport(ctx, &ImgixReport{
+		ReportType:  data.ReportType.ValueString(),
+		PeriodStart: start.Unix(),
+		PeriodEnd:   end.AddDate(0, 0, 1).Unix() - 1,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while requesting the report. "+
+				"Please report this issue to the provider developers.\n\n"+
+				"Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	// Save the pending report right away, so it isn't requested again if waiting fails
+	convertReportToReportModel(report, data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Wait for the report to be generated, a later refresh picks it up if it isn't ready in time
+	report, err = waitForReport(ctx, r.client, report)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Report Still Pending",
+			"Report "+report.ID+" was requested but didn't complete yet, a later refresh will pick up its final status.\n\n"+
+				"Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	convertReportToReportModel(report, data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// waitForReport polls the report until it's completed, returning the last known report on error.
+func waitForReport(ctx context.Context, client *ImgixClient, report *ImgixReport) (*ImgixReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, reportPollTimeout)
+	defer cancel()
+	for !report.Completed {
+		tflog.Debug(ctx, "Waiting for report to complete", map[string]interface{}{"id": report.ID})
+		select {
+		case <-ctx.Done():
+			return report, fmt.Errorf("report didn't complete in %s: %w", reportPollTimeout, ctx.Err())
+		case <-time.After(reportPollInterval):
+		}
+		r, err := client.GetReportByID(ctx, report.ID)
+		if err != nil {
+			return report, err
+		}
+		report = r
+	}
+	return report, nil
+}
+
+func (r *ReportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform state data into the model
+	data := new(ReportModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch our remote data, the download URL may have been refreshed
+	report, err := r.client.GetReportByID(ctx, data.ID.ValueString())
+	var notFound *ReportNotFoundError
+	if errors.As(err, &notFound) {
+		tflog.Warn(ctx, "report not found, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch report by ID", err.Error())
+		return
+	}
+
+	// Set our state
+	convertReportToReportModel(report, data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r ReportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument requires replacement, so there's nothing to request here
+	data := new(ReportModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r ReportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reports can't be deleted, removing the resource from state is enough
+}
+
+func convertReportToReportModel(report *ImgixReport, targetReportModel *ReportModel) {
+	targetReportModel.ID = types.StringValue(report.ID)
+	targetReportModel.URL = types.StringValue(report.URL)
+	if report.Completed {
+		targetReportModel.Status = types.StringValue("completed")
+	} else {
+		targetReportModel.Status = types.StringValue("pending")
+	}
+}
@@ -3,13 +3,16 @@ package internal
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -22,30 +25,173 @@ func NewSourceResource() resource.Resource {
 // Ensure the implementation satisfies the resource.ResourceWithConfigure interface.
 var _ resource.ResourceWithConfigure = &SourceResource{}
 
+// Ensure the implementation satisfies the resource.ResourceWithUpgradeState interface.
+var _ resource.ResourceWithUpgradeState = &SourceResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithValidateConfig interface.
+var _ resource.ResourceWithValidateConfig = &SourceResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithModifyPlan interface.
+var _ resource.ResourceWithModifyPlan = &SourceResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithIdentity interface.
+var _ resource.ResourceWithIdentity = &SourceResource{}
+
 type SourceResource struct {
 	client *ImgixClient
 }
 
+// SourceResourceModel is the Terraform model of the source resource. The deployment
+// can be configured either through the deprecated `deployment` block or the
+// `deployment_config` attribute, but never both.
+type SourceResourceModel struct {
+	ID               types.String             `tfsdk:"id"`
+	Name             types.String             `tfsdk:"name"`
+	Deployment       *DeploymentResourceModel `tfsdk:"deployment"`
+	DeploymentConfig *DeploymentResourceModel `tfsdk:"deployment_config"`
+	DeploymentExtra  types.String             `tfsdk:"deployment_extra"`
+	Enabled          types.Bool               `tfsdk:"enabled"`
+	PurgeOnChange    *PurgeOnChangeModel      `tfsdk:"purge_on_change"`
+}
+
+// SourceIdentityModel is the identity of a source, used by imports and `terraform query`.
+type SourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+type PurgeOnChangeModel struct {
+	Paths    types.List `tfsdk:"paths"`
+	SubImage types.Bool `tfsdk:"sub_image"`
+}
+
+// DeploymentResourceModel extends the shared deployment model with the attributes
+// that only exist on the resource.
+type DeploymentResourceModel struct {
+	DeploymentModel
+	S3SecretKeyWO        types.String `tfsdk:"s3_secret_key_wo"`
+	S3SecretKeyWOVersion types.Int64  `tfsdk:"s3_secret_key_wo_version"`
+	S3SecretKeyHash      types.String `tfsdk:"s3_secret_key_hash"`
+}
+
+// deployment returns whichever deployment is configured.
+func (m *SourceResourceModel) deployment() *DeploymentResourceModel {
+	if m.DeploymentConfig != nil {
+		return m.DeploymentConfig
+	}
+	return m.Deployment
+}
+
+// deploymentPath returns the path of whichever deployment is configured.
+func (m *SourceResourceModel) deploymentPath() path.Path {
+	if m.Deployment != nil && m.DeploymentConfig == nil {
+		return path.Root("deployment")
+	}
+	return path.Root("deployment_config")
+}
+
+// sourceModel returns the shared source model using whichever deployment is configured.
+func (m *SourceResourceModel) sourceModel() *SourceModel {
+	model := &SourceModel{
+		ID:      m.ID,
+		Name:    m.Name,
+		Enabled: m.Enabled,
+	}
+	if deployment := m.deployment(); deployment != nil {
+		model.Deployment = &deployment.DeploymentModel
+	}
+	return model
+}
+
+// newSourceResourceModel builds the resource model from the shared source model, keeping
+// the deployment in the same place as the prior model had it. Sources without a prior
+// deployment (e.g. imports) use `deployment_config`.
+func newSourceResourceModel(source *SourceModel, prior *SourceResourceModel) *SourceResourceModel {
+	model := &SourceResourceModel{
+		ID:              source.ID,
+		Name:            source.Name,
+		DeploymentExtra: types.StringNull(),
+		Enabled:         source.Enabled,
+	}
+	if prior != nil {
+		model.DeploymentExtra = prior.DeploymentExtra
+		model.PurgeOnChange = prior.PurgeOnChange
+	}
+	if source.Deployment == nil {
+		return model
+	}
+
+	// Imgix never returns secrets, so keep what was configured along with the hash of the
+	// applied secret. Write-only values are never persisted.
+	deployment := &DeploymentResourceModel{
+		DeploymentModel:      *source.Deployment,
+		S3SecretKeyWO:        types.StringNull(),
+		S3SecretKeyWOVersion: types.Int64Null(),
+		S3SecretKeyHash:      types.StringNull(),
+	}
+	deployment.S3SecretKey = types.StringNull()
+	if prior != nil && prior.deployment() != nil {
+		deployment.S3SecretKey = prior.deployment().S3SecretKey
+		deployment.S3SecretKeyWOVersion = prior.deployment().S3SecretKeyWOVersion
+		deployment.S3SecretKeyHash = prior.deployment().S3SecretKeyHash
+	}
+
+	if prior != nil && prior.Deployment != nil && prior.DeploymentConfig == nil {
+		model.Deployment = deployment
+	} else {
+		model.DeploymentConfig = deployment
+	}
+	return model
+}
+
+func resourceDeployAttributes(computed, required bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"annotation": schema.StringAttribute{Required: required, Computed: computed},
+		"type":       schema.StringAttribute{Required: required, Computed: computed},
+		"s3_bucket":  schema.StringAttribute{Optional: required, Computed: computed},
+		"s3_prefix":  schema.StringAttribute{Optional: required, Computed: computed},
+		"s3_access_key": schema.StringAttribute{
+			Optional:  required,
+			Computed:  computed,
+			Sensitive: true,
+		},
+		"s3_secret_key": schema.StringAttribute{
+			Optional:            required,
+			Computed:            computed,
+			Sensitive:           true,
+			MarkdownDescription: "Imgix never returns this value, so the configured value is kept in state. Use `s3_secret_key_wo` to keep it out of state.",
+		},
+		"s3_secret_key_wo": schema.StringAttribute{
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			MarkdownDescription: "Write-only alternative to `s3_secret_key` that is never stored in state. Requires Terraform 1.11 or later.",
+		},
+		"s3_secret_key_wo_version": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Version of `s3_secret_key_wo`, changing this value sends the current `s3_secret_key_wo` to Imgix even if it didn't change.",
+		},
+		"s3_secret_key_hash": schema.StringAttribute{
+			Computed:            true,
+			PlanModifiers:       []planmodifier.String{SecretHashModifier("s3_secret_key_wo", "s3_secret_key")},
+			MarkdownDescription: "Salted hash of the secret key last sent to Imgix, used to detect when the configured secret key changes.",
+		},
+		"imgix_subdomains": schema.SetAttribute{ElementType: types.StringType, Required: required, Computed: computed},
+	}
+}
+
 func resourceDeployObjectType(computed, required bool) schema.Block {
 	return schema.SingleNestedBlock{
-		Attributes: map[string]schema.Attribute{
-			"annotation": schema.StringAttribute{Required: required, Computed: computed},
-			"type":       schema.StringAttribute{Required: required, Computed: computed},
-			"s3_bucket":  schema.StringAttribute{Optional: required, Computed: computed},
-			"s3_prefix":  schema.StringAttribute{Optional: required, Computed: computed},
-			"s3_access_key": schema.StringAttribute{
-				Optional:  required,
-				Computed:  computed,
-				Sensitive: true,
-			},
-			"s3_secret_key": schema.StringAttribute{
-				Optional:      required,
-				Computed:      computed,
-				PlanModifiers: []planmodifier.String{UseStateAfterSetModifier()},
-				Sensitive:     true,
-			},
-			"imgix_subdomains": schema.ListAttribute{ElementType: types.StringType, Required: required, Computed: computed},
-		},
+		Attributes:         resourceDeployAttributes(computed, required),
+		DeprecationMessage: "The `deployment` block is deprecated and will be removed in a future release, use the `deployment_config` attribute instead.",
+	}
+}
+
+func resourceDeployNestedAttribute(computed, required bool) schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Attributes: resourceDeployAttributes(computed, required),
+		Optional:   true,
+		MarkdownDescription: "The deployment settings of the source. " +
+			"Exactly one of `deployment_config` or the deprecated `deployment` block must be set.",
 	}
 }
 
@@ -55,6 +201,7 @@ func (d *SourceResource) Metadata(ctx context.Context, req resource.MetadataRequ
 
 func (r SourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 3,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -62,8 +209,32 @@ func (r SourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"name":    schema.StringAttribute{Required: true},
-			"enabled": schema.BoolAttribute{Required: true},
+			"name":              schema.StringAttribute{Required: true},
+			"enabled":           schema.BoolAttribute{Required: true},
+			"deployment_config": resourceDeployNestedAttribute(false, true),
+			"deployment_extra": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "JSON encoded object of deployment fields that aren't supported natively yet, " +
+					"merged into the deployment sent to Imgix. Only the keys set here are checked for drift.",
+			},
+			"purge_on_change": schema.SingleNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Purge the given paths from every Imgix subdomain of the source after an update changes " +
+					"rendering settings (`default_params`, `image_error`, `image_missing` or cache TTLs in `deployment_extra`) " +
+					"or the origin (`type`, `s3_bucket` or `s3_prefix` of the deployment). Other deployment attributes, e.g. " +
+					"credentials or subdomains, don't trigger purges. Failed purges are reported as warnings.",
+				Attributes: map[string]schema.Attribute{
+					"paths": schema.ListAttribute{
+						ElementType:         types.StringType,
+						Required:            true,
+						MarkdownDescription: "Paths to purge, e.g. `/images/logo.png`.",
+					},
+					"sub_image": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Also purge images that use the paths as a watermark, blend or other sub-image.",
+					},
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"deployment": resourceDeployObjectType(false, true),
@@ -71,6 +242,146 @@ func (r SourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+// IdentitySchema identifies a source by its ID, for imports and `terraform query`.
+func (r SourceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the source.",
+			},
+		},
+	}
+}
+
+// ValidateConfig satisfies the resource.ResourceWithValidateConfig interface for SourceResource.
+func (r SourceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var deployment, deploymentConfig types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deployment"), &deployment)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deployment_config"), &deploymentConfig)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate the extra deployment fields
+	var deploymentExtra types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deployment_extra"), &deploymentExtra)...)
+	if _, err := decodeDeploymentExtra(deploymentExtra); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("deployment_extra"), "Invalid Deployment Extra", err.Error())
+	}
+
+	// Validate the secrets of whichever deployment is set
+	resp.Diagnostics.Append(validateDeploymentSecretKey(ctx, req.Config, path.Root("deployment"))...)
+	resp.Diagnostics.Append(validateDeploymentSecretKey(ctx, req.Config, path.Root("deployment_config"))...)
+
+	// We can't tell which one will be set until the values are known
+	if deployment.IsUnknown() || deploymentConfig.IsUnknown() {
+		return
+	}
+
+	if deployment.IsNull() && deploymentConfig.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deployment_config"),
+			"Missing Deployment Configuration",
+			"A source requires its deployment settings, please set the `deployment_config` attribute.",
+		)
+	} else if !deployment.IsNull() && !deploymentConfig.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deployment_config"),
+			"Conflicting Deployment Configuration",
+			"Only one of `deployment_config` or the deprecated `deployment` block can be set.",
+		)
+	}
+}
+
+func validateDeploymentSecretKey(ctx context.Context, config tfsdk.Config, deploymentPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var secretKey, secretKeyWO types.String
+	var secretKeyWOVersion types.Int64
+	diags.Append(config.GetAttribute(ctx, deploymentPath.AtName("s3_secret_key"), &secretKey)...)
+	diags.Append(config.GetAttribute(ctx, deploymentPath.AtName("s3_secret_key_wo"), &secretKeyWO)...)
+	diags.Append(config.GetAttribute(ctx, deploymentPath.AtName("s3_secret_key_wo_version"), &secretKeyWOVersion)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !secretKey.IsNull() && !secretKeyWO.IsNull() {
+		diags.AddAttributeError(
+			deploymentPath.AtName("s3_secret_key_wo"),
+			"Conflicting Secret Key Configuration",
+			"Only one of `s3_secret_key` or `s3_secret_key_wo` can be set.",
+		)
+	}
+	if secretKeyWO.IsNull() && !secretKeyWOVersion.IsNull() {
+		diags.AddAttributeWarning(
+			deploymentPath.AtName("s3_secret_key_wo_version"),
+			"Unused Secret Key Version",
+			"`s3_secret_key_wo_version` only has an effect when `s3_secret_key_wo` is set.",
+		)
+	}
+	return diags
+}
+
+// ModifyPlan satisfies the resource.ResourceWithModifyPlan interface for SourceResource.
+func (r SourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to migrate on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	plan := new(SourceResourceModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	state := new(SourceResourceModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() || !deploymentMigrated(plan, state) {
+		return
+	}
+
+	// Moving the deprecated block to `deployment_config` doesn't change anything on Imgix,
+	// so carry the hash of the secret key over rather than planning to send it again
+	if !deploymentsEqual(plan.DeploymentConfig, state.Deployment) {
+		return
+	}
+	secretKey, diags := getSecretKey(ctx, req.Config, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || secretKey.IsUnknown() {
+		return
+	}
+	hash := state.Deployment.S3SecretKeyHash
+	if secretKey.IsNull() != hash.IsNull() || (!hash.IsNull() && !verifySecretHash(secretKey.ValueString(), hash.ValueString())) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deployment_config").AtName("s3_secret_key_hash"), hash)...)
+}
+
+// deploymentMigrated reports whether the deployment moved from the deprecated block to `deployment_config`.
+func deploymentMigrated(plan, state *SourceResourceModel) bool {
+	return state.Deployment != nil && state.DeploymentConfig == nil && plan.Deployment == nil && plan.DeploymentConfig != nil
+}
+
+// deploymentsEqual reports whether both deployments send the same settings to Imgix,
+// apart from the secret key which is only compared through its hash.
+func deploymentsEqual(a, b *DeploymentResourceModel) bool {
+	return a.Annotation.Equal(b.Annotation) &&
+		a.Type.Equal(b.Type) &&
+		a.S3Bucket.Equal(b.S3Bucket) &&
+		a.S3Prefix.Equal(b.S3Prefix) &&
+		a.S3AccessKey.Equal(b.S3AccessKey) &&
+		a.ImgixSubdomains.Equal(b.ImgixSubdomains) &&
+		a.S3SecretKeyWOVersion.Equal(b.S3SecretKeyWOVersion)
+}
+
+// onlyDeploymentMigrated reports whether moving the deployment to `deployment_config` is
+// the only planned change, in which case nothing needs to be sent to Imgix.
+func onlyDeploymentMigrated(plan, state *SourceResourceModel) bool {
+	return deploymentMigrated(plan, state) &&
+		deploymentsEqual(plan.DeploymentConfig, state.Deployment) &&
+		plan.DeploymentConfig.S3SecretKeyHash.Equal(state.Deployment.S3SecretKeyHash) &&
+		plan.Name.Equal(state.Name) &&
+		plan.Enabled.Equal(state.Enabled) &&
+		plan.DeploymentExtra.Equal(state.DeploymentExtra)
+}
+
 func (d *SourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// This isn't always called so don't panic yet
 	if req.ProviderData == nil {
@@ -98,7 +409,7 @@ func (r SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Read Terraform plan data into the model
-	data := new(SourceModel)
+	data := new(SourceResourceModel)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -106,12 +417,28 @@ func (r SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Convert from Terraform data model into API data model
 	localSource := new(ImgixSource)
-	diags := convertSourceModelToSource(ctx, data, localSource)
+	diags := convertSourceModelToSource(ctx, data.sourceModel(), localSource)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Write-only values are only available in the configuration
+	secretKey, diags := getSecretKey(ctx, req.Config, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	localSource.Deployment.S3SecretKey = secretKey.ValueString()
+
+	// Merge in the deployment fields we don't support natively
+	extra, err := decodeDeploymentExtra(data.DeploymentExtra)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("deployment_extra"), "Invalid Deployment Extra", err.Error())
+		return
+	}
+	localSource.Deployment.Extra = extra
+
 	// If upsert_by_name = true, try to "create" an existing resource by syncing state
 	// and updating enabled = true
 	var source *ImgixSource
@@ -181,18 +508,24 @@ func (r SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Convert our remote struct into our terraform model
 	state := new(SourceModel)
-	diags = convertSourceToSourceModel(ctx, source, data, state)
+	diags = convertSourceToSourceModel(ctx, source, data.sourceModel(), state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	model := newSourceResourceModel(state, data)
+	resp.Diagnostics.Append(setSecretKeyHash(model, secretKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+	resp.Diagnostics.Append(setSourceIdentity(ctx, resp.Identity, model.ID)...)
 }
 
 func (r *SourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -206,7 +539,7 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	// Read Terraform state data into the model
-	data := new(SourceModel)
+	data := new(SourceResourceModel)
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -221,14 +554,115 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Convert our remote data to local
 	state := new(SourceModel)
-	diag := convertSourceToSourceModel(ctx, source, data, state)
+	diag := convertSourceToSourceModel(ctx, source, data.sourceModel(), state)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Only the keys we set in deployment_extra are checked for drift
+	model := newSourceResourceModel(state, data)
+	model.DeploymentExtra, err = refreshDeploymentExtra(model.DeploymentExtra, source.Deployment.Raw)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("deployment_extra"), "Invalid Deployment Extra", err.Error())
+		return
+	}
+
 	// Set our state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+	resp.Diagnostics.Append(setSourceIdentity(ctx, resp.Identity, model.ID)...)
+}
+
+// setSourceIdentity sets the identity of the source, when Terraform supports identities.
+func setSourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, SourceIdentityModel{ID: id})
+}
+
+// getSecretKey returns the secret key of the configured deployment, preferring the
+// write-only one which can only be read from the configuration.
+func getSecretKey(ctx context.Context, config tfsdk.Config, model *SourceResourceModel) (types.String, diag.Diagnostics) {
+	var secretKeyWO types.String
+	diags := config.GetAttribute(ctx, model.deploymentPath().AtName("s3_secret_key_wo"), &secretKeyWO)
+	if diags.HasError() || !secretKeyWO.IsNull() {
+		return secretKeyWO, diags
+	}
+	if deployment := model.deployment(); deployment != nil {
+		return deployment.S3SecretKey, diags
+	}
+	return types.StringNull(), diags
+}
+
+// secretKeyChanged reports whether the planned secret key differs from the one last sent to Imgix.
+func secretKeyChanged(plan, state *SourceResourceModel) bool {
+	planned, current := plan.deployment(), state.deployment()
+	if planned == nil {
+		return false
+	}
+	if current == nil {
+		return true
+	}
+	return planned.S3SecretKeyHash.IsUnknown() || !planned.S3SecretKeyWOVersion.Equal(current.S3SecretKeyWOVersion)
+}
+
+// setSecretKeyHash stores the hash of the applied secret key when it couldn't be known during plan.
+func setSecretKeyHash(model *SourceResourceModel, secretKey types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	deployment := model.deployment()
+	if deployment == nil || !deployment.S3SecretKeyHash.IsUnknown() {
+		return diags
+	}
+	if secretKey.IsNull() {
+		deployment.S3SecretKeyHash = types.StringNull()
+		return diags
+	}
+	hash, err := hashSecret(secretKey.ValueString())
+	if err != nil {
+		diags.AddError("Unable to Hash Secret Key", err.Error())
+		return diags
+	}
+	deployment.S3SecretKeyHash = types.StringValue(hash)
+	return diags
+}
+
+// purgeNeeded reports whether the update changes how images are rendered, either through
+// the rendering fields of `deployment_extra` or by pointing the source to another origin.
+func purgeNeeded(state, plan *SourceResourceModel) bool {
+	if renderingSettingsChanged(state.DeploymentExtra, plan.DeploymentExtra) {
+		return true
+	}
+	prior, planned := state.deployment(), plan.deployment()
+	if prior == nil || planned == nil {
+		return prior != planned
+	}
+	return !prior.Type.Equal(planned.Type) || !prior.S3Bucket.Equal(planned.S3Bucket) || !prior.S3Prefix.Equal(planned.S3Prefix)
+}
+
+// purgeSourcePaths purges the paths from every Imgix subdomain of the source. The update
+// already went through, so failures are only reported as warnings.
+func purgeSourcePaths(ctx context.Context, client *ImgixClient, source *ImgixSource, purgeOnChange *PurgeOnChangeModel) diag.Diagnostics {
+	var paths []string
+	diags := purgeOnChange.Paths.ElementsAs(ctx, &paths, false)
+	if diags.HasError() {
+		return diags
+	}
+	for _, subdomain := range source.Deployment.ImgixSubdomains {
+		for _, p := range paths {
+			url := "https://" + subdomain + ".imgix.net/" + strings.TrimPrefix(p, "/")
+			tflog.Debug(ctx, "Purging URL after rendering settings changed", map[string]interface{}{"url": url})
+			_, err := client.CreatePurge(ctx, &ImgixPurge{URL: url, SourceID: source.ID, SubImage: purgeOnChange.SubImage.ValueBool()})
+			if err != nil {
+				diags.AddWarning(
+					"Unable to Purge URL",
+					"The source was updated but purging "+url+" failed, stale images may be served until the cache expires.\n\n"+
+						"Client Error: "+err.Error(),
+				)
+			}
+		}
+	}
+	return diags
 }
 
 func updateSourceEnabledAttribute(ctx context.Context, client *ImgixClient, sourceID string, enabled bool) error {
@@ -247,28 +681,44 @@ func (r SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Read Terraform plan into the model
-	oldState := new(SourceModel)
+	oldState := new(SourceResourceModel)
 	resp.Diagnostics.Append(req.State.Get(ctx, &oldState)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Read Terraform plan into the model
-	plan := new(SourceModel)
+	plan := new(SourceResourceModel)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	plan.ID = oldState.ID
 
+	// Configurations moving off the deprecated block only need their state updated
+	if onlyDeploymentMigrated(plan, oldState) {
+		tflog.Debug(ctx, "deployment moved to deployment_config, nothing to send to imgix")
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		resp.Diagnostics.Append(setSourceIdentity(ctx, resp.Identity, plan.ID)...)
+		return
+	}
+
 	// Convert from Terraform data model into API data model
 	source := new(ImgixSource)
-	diags := convertSourceModelToSource(ctx, plan, source)
+	diags := convertSourceModelToSource(ctx, plan.sourceModel(), source)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Merge in the deployment fields we don't support natively
+	extra, err := decodeDeploymentExtra(plan.DeploymentExtra)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("deployment_extra"), "Invalid Deployment Extra", err.Error())
+		return
+	}
+	source.Deployment.Extra = extra
+
 	// Imgix won't allow updates to a disabled source, gather the state and determine the actions
 	enabledState := oldState.Enabled.ValueBoolPointer()
 	enabledPlan := plan.Enabled.ValueBool()
@@ -302,9 +752,16 @@ func (r SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 	}
 
-	// Don't pass down our placeholder to update
-	if source.Deployment.S3SecretKey == SECRET_KEY_PLACEHOLDER {
-		source.Deployment.S3SecretKey = ""
+	// Imgix never returns the secret key, so only send it when it changed
+	secretKey, diags := getSecretKey(ctx, req.Config, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	source.Deployment.S3SecretKey = ""
+	if secretKeyChanged(plan, oldState) {
+		tflog.Debug(ctx, "secret key changed, sending it to imgix")
+		source.Deployment.S3SecretKey = secretKey.ValueString()
 	}
 
 	// We have to update our data before we disable if we have other things planned, so set `enabled = true` for now
@@ -314,7 +771,7 @@ func (r SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update our data in remote
-	_, err := r.client.UpdateSource(ctx, source)
+	_, err = r.client.UpdateSource(ctx, source)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Update Resource",
@@ -346,16 +803,27 @@ func (r SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// Purge stale derivatives if the rendering settings changed
+	if plan.PurgeOnChange != nil && enabledPlan && purgeNeeded(oldState, plan) {
+		resp.Diagnostics.Append(purgeSourcePaths(ctx, r.client, source, plan.PurgeOnChange)...)
+	}
+
 	// Convert our remote data to local
 	state := new(SourceModel)
-	diag := convertSourceToSourceModel(ctx, source, plan, state)
+	diag := convertSourceToSourceModel(ctx, source, plan.sourceModel(), state)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	model := newSourceResourceModel(state, plan)
+	resp.Diagnostics.Append(setSecretKeyHash(model, secretKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set our state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+	resp.Diagnostics.Append(setSourceIdentity(ctx, resp.Identity, model.ID)...)
 }
 
 func (r SourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -369,7 +837,7 @@ func (r SourceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	// Read Terraform prior state data into the model
-	data := new(SourceModel)
+	data := new(SourceResourceModel)
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
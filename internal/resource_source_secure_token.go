@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// With the resource.Resource implementation
+func NewSourceSecureTokenResource() resource.Resource {
+	return &SourceSecureTokenResource{}
+}
+
+// Ensure the implementation satisfies the resource.ResourceWithConfigure interface.
+var _ resource.ResourceWithConfigure = &SourceSecureTokenResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithValidateConfig interface.
+var _ resource.ResourceWithValidateConfig = &SourceSecureTokenResource{}
+
+// Ensure the implementation satisfies the resource.ResourceWithImportState interface.
+var _ resource.ResourceWithImportState = &SourceSecureTokenResource{}
+
+type SourceSecureTokenResource struct {
+	client *ImgixClient
+}
+
+type SourceSecureTokenModel struct {
+	ID                     types.String `tfsdk:"id"`
+	SourceID               types.String `tfsdk:"source_id"`
+	GracePeriod            types.String `tfsdk:"grace_period"`
+	SecureURLToken         types.String `tfsdk:"secure_url_token"`
+	PreviousSecureURLToken types.String `tfsdk:"previous_secure_url_token"`
+	PreviousExpiresAt      types.String `tfsdk:"previous_expires_at"`
+	RotatedAt              types.String `tfsdk:"rotated_at"`
+}
+
+func (r *SourceSecureTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_secure_token"
+}
+
+func (r SourceSecureTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computedString := func(description string, sensitive bool) schema.StringAttribute {
+		return schema.StringAttribute{
+			Computed:            true,
+			Sensitive:           sensitive,
+			MarkdownDescription: description,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tracks the secure URL token of a source. The token is regenerated from the Imgix dashboard, " +
+			"refreshing this resource picks up the new token and keeps the previous one for `grace_period`. " +
+			"Destroying this resource doesn't change the token.",
+		Attributes: map[string]schema.Attribute{
+			"id": computedString("Same as `source_id`.", false),
+			"source_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the source.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"grace_period": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How long to keep the previous token once a new one is picked up as a duration, e.g. `24h`, " +
+					"so apps can accept URLs signed with either token.",
+			},
+			"secure_url_token":          computedString("The current secure URL token.", true),
+			"previous_secure_url_token": computedString("The token before the last regeneration, until `previous_expires_at`.", true),
+			"previous_expires_at":       computedString("When the grace period of the previous token ends, in RFC 3339 format.", false),
+			"rotated_at":                computedString("When this resource last picked up a regenerated token, in RFC 3339 format.", false),
+		},
+	}
+}
+
+func (r *SourceSecureTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// This isn't always called so don't panic yet
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*ImgixClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ImgixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r SourceSecureTokenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	data := new(SourceSecureTokenModel)
+	resp.Diagnostics.Append(req.Config.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.GracePeriod.IsNull() && !data.GracePeriod.IsUnknown() {
+		if gracePeriod, err := time.ParseDuration(data.GracePeriod.ValueString()); err != nil || gracePeriod < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("grace_period"),
+				"Invalid Grace Period",
+				fmt.Sprintf("The grace_period must be a duration, e.g. `24h`, got: %q.", data.GracePeriod.ValueString()),
+			)
+		}
+	}
+}
+
+func (r SourceSecureTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform plan data into the model
+	data := new(SourceSecureTokenModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Adopt the current token
+	source, err := r.client.GetSourceByID(ctx, data.SourceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get source", err.Error())
+		return
+	}
+	data.ID = types.StringValue(source.ID)
+	data.SecureURLToken = types.StringValue(source.SecureURLToken)
+	data.PreviousSecureURLToken = types.StringNull()
+	data.PreviousExpiresAt = types.StringNull()
+	data.RotatedAt = types.StringNull()
+
+	// Set our state
+	tflog.Trace(ctx, "adopted the secure URL token of a source", map[string]interface{}{"source_id": source.ID})
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *SourceSecureTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	// Read Terraform state data into the model
+	data := new(SourceSecureTokenModel)
+	resp.Diagnostics.Append(req.State.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch our remote data, the token may have been regenerated from the dashboard
+	source, err := r.client.GetSourceByID(ctx, data.SourceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get source", err.Error())
+		return
+	}
+	if token := source.SecureURLToken; token != data.SecureURLToken.ValueString() {
+		adoptRegeneratedToken(data, token, time.Now().UTC())
+	}
+	expirePreviousToken(data)
+
+	// Set our state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r SourceSecureTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only the grace period can change, which applies to the next token picked up
+	data := new(SourceSecureTokenModel)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r SourceSecureTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The token stays on the source, the resource is just removed from the state
+	tflog.Trace(ctx, "removed a secure URL token from the state, the token is unchanged")
+}
+
+func (r *SourceSecureTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), req.ID)...)
+}
+
+// adoptRegeneratedToken replaces the token, keeping the previous one for the grace period.
+func adoptRegeneratedToken(data *SourceSecureTokenModel, token string, now time.Time) {
+	var gracePeriod time.Duration
+	if !data.GracePeriod.IsNull() {
+		// ValidateConfig already rejected invalid durations
+		gracePeriod, _ = time.ParseDuration(data.GracePeriod.ValueString())
+	}
+	data.PreviousSecureURLToken = types.StringNull()
+	data.PreviousExpiresAt = types.StringNull()
+	if gracePeriod > 0 && data.SecureURLToken.ValueString() != "" {
+		data.PreviousSecureURLToken = data.SecureURLToken
+		data.PreviousExpiresAt = types.StringValue(now.Add(gracePeriod).Format(time.RFC3339))
+	}
+	data.SecureURLToken = types.StringValue(token)
+	data.RotatedAt = types.StringValue(now.Format(time.RFC3339))
+}
+
+// expirePreviousToken drops the previous token once its grace period is over.
+func expirePreviousToken(data *SourceSecureTokenModel) {
+	if data.PreviousExpiresAt.IsNull() || data.PreviousExpiresAt.IsUnknown() {
+		return
+	}
+	expiresAt, err := time.Parse(time.RFC3339, data.PreviousExpiresAt.ValueString())
+	if err != nil || time.Now().After(expiresAt) {
+		data.PreviousSecureURLToken = types.StringNull()
+		data.PreviousExpiresAt = types.StringNull()
+	}
+}
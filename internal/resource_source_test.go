@@ -0,0 +1,271 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testDeployment(bucket string) *DeploymentResourceModel {
+	return &DeploymentResourceModel{
+		DeploymentModel: DeploymentModel{
+			Annotation:      types.StringValue("profile"),
+			Type:            types.StringValue("s3"),
+			S3Bucket:        types.StringValue(bucket),
+			S3Prefix:        types.StringNull(),
+			S3AccessKey:     types.StringValue("AKIA"),
+			S3SecretKey:     types.StringNull(),
+			ImgixSubdomains: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("profile")}),
+		},
+		S3SecretKeyWO:        types.StringNull(),
+		S3SecretKeyWOVersion: types.Int64Null(),
+		S3SecretKeyHash:      types.StringValue("salt$hash"),
+	}
+}
+
+func testSourceResourceModel(deployment, deploymentConfig *DeploymentResourceModel) *SourceResourceModel {
+	return &SourceResourceModel{
+		ID:               types.StringValue("1"),
+		Name:             types.StringValue("profile"),
+		Enabled:          types.BoolValue(true),
+		DeploymentExtra:  types.StringNull(),
+		Deployment:       deployment,
+		DeploymentConfig: deploymentConfig,
+	}
+}
+
+func TestOnlyDeploymentMigrated(t *testing.T) {
+	renamed := testSourceResourceModel(nil, testDeployment("images"))
+	renamed.Name = types.StringValue("renamed")
+	newHash := testDeployment("images")
+	newHash.S3SecretKeyHash = types.StringUnknown()
+
+	tests := []struct {
+		name     string
+		plan     *SourceResourceModel
+		state    *SourceResourceModel
+		migrated bool
+		only     bool
+	}{
+		{
+			name:     "moved to deployment_config",
+			plan:     testSourceResourceModel(nil, testDeployment("images")),
+			state:    testSourceResourceModel(testDeployment("images"), nil),
+			migrated: true,
+			only:     true,
+		},
+		{
+			name:     "moved with a new bucket",
+			plan:     testSourceResourceModel(nil, testDeployment("other")),
+			state:    testSourceResourceModel(testDeployment("images"), nil),
+			migrated: true,
+		},
+		{
+			name:     "moved with a new secret key",
+			plan:     testSourceResourceModel(nil, newHash),
+			state:    testSourceResourceModel(testDeployment("images"), nil),
+			migrated: true,
+		},
+		{
+			name:     "moved and renamed",
+			plan:     renamed,
+			state:    testSourceResourceModel(testDeployment("images"), nil),
+			migrated: true,
+		},
+		{
+			name:  "already on deployment_config",
+			plan:  testSourceResourceModel(nil, testDeployment("images")),
+			state: testSourceResourceModel(nil, testDeployment("images")),
+		},
+		{
+			name:  "still on the block",
+			plan:  testSourceResourceModel(testDeployment("images"), nil),
+			state: testSourceResourceModel(testDeployment("images"), nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deploymentMigrated(tt.plan, tt.state); got != tt.migrated {
+				t.Errorf("deploymentMigrated() = %v, want %v", got, tt.migrated)
+			}
+			if got := onlyDeploymentMigrated(tt.plan, tt.state); got != tt.only {
+				t.Errorf("onlyDeploymentMigrated() = %v, want %v", got, tt.only)
+			}
+		})
+	}
+}
+
+func TestUpgradedDeployment(t *testing.T) {
+	stored := testDeployment("images").DeploymentModel
+	stored.S3SecretKey = types.StringValue("sk")
+	upgraded := upgradedDeployment(&stored)
+	if upgraded.S3SecretKey.ValueString() != "sk" {
+		t.Errorf("s3_secret_key = %s, want the stored secret key", upgraded.S3SecretKey)
+	}
+	if !verifySecretHash("sk", upgraded.S3SecretKeyHash.ValueString()) {
+		t.Errorf("s3_secret_key_hash = %s, doesn't match the stored secret key", upgraded.S3SecretKeyHash)
+	}
+
+	// The placeholder of the data source was never a real secret
+	placeholder := testDeployment("images").DeploymentModel
+	placeholder.S3SecretKey = types.StringValue(SECRET_KEY_PLACEHOLDER)
+	if upgraded := upgradedDeployment(&placeholder); !upgraded.S3SecretKeyHash.IsNull() {
+		t.Errorf("s3_secret_key_hash = %s, want null", upgraded.S3SecretKeyHash)
+	}
+}
+
+func TestNewSourceResourceModelKeepsSecretKey(t *testing.T) {
+	prior := testSourceResourceModel(nil, testDeployment("images"))
+	prior.DeploymentConfig.S3SecretKey = types.StringValue("sk")
+	source := &SourceModel{
+		ID:         types.StringValue("1"),
+		Name:       types.StringValue("profile"),
+		Enabled:    types.BoolValue(true),
+		Deployment: &testDeployment("images").DeploymentModel,
+	}
+	source.Deployment.S3SecretKey = types.StringValue(SECRET_KEY_PLACEHOLDER)
+
+	model := newSourceResourceModel(source, prior)
+	if model.DeploymentConfig.S3SecretKey.ValueString() != "sk" {
+		t.Errorf("s3_secret_key = %s, want the configured secret key", model.DeploymentConfig.S3SecretKey)
+	}
+	if model.DeploymentConfig.S3SecretKeyHash.ValueString() != "salt$hash" {
+		t.Errorf("s3_secret_key_hash = %s, want the prior hash", model.DeploymentConfig.S3SecretKeyHash)
+	}
+}
+
+func TestPurgeNeeded(t *testing.T) {
+	extra := func(value string) types.String {
+		if value == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(value)
+	}
+	withPrefix := testDeployment("images")
+	withPrefix.S3Prefix = types.StringValue("v2/")
+	newAccessKey := testDeployment("images")
+	newAccessKey.S3AccessKey = types.StringValue("AKIA2")
+
+	tests := []struct {
+		name       string
+		prior      *DeploymentResourceModel
+		planned    *DeploymentResourceModel
+		priorExtra string
+		extra      string
+		want       bool
+	}{
+		{name: "nothing changed", prior: testDeployment("images"), planned: testDeployment("images")},
+		{name: "default_params added", prior: testDeployment("images"), planned: testDeployment("images"), extra: `{"default_params":{"auto":"format"}}`, want: true},
+		{name: "cache TTL changed", prior: testDeployment("images"), planned: testDeployment("images"), priorExtra: `{"cache_ttl_value":60}`, extra: `{"cache_ttl_value":3600}`, want: true},
+		{name: "other extra field changed", prior: testDeployment("images"), planned: testDeployment("images"), extra: `{"secure_url_enabled":true}`},
+		{name: "bucket changed", prior: testDeployment("images"), planned: testDeployment("other"), want: true},
+		{name: "prefix changed", prior: testDeployment("images"), planned: withPrefix, want: true},
+		{name: "credentials changed", prior: testDeployment("images"), planned: newAccessKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := testSourceResourceModel(nil, tt.prior)
+			state.DeploymentExtra = extra(tt.priorExtra)
+			plan := testSourceResourceModel(nil, tt.planned)
+			plan.DeploymentExtra = extra(tt.extra)
+			if got := purgeNeeded(state, plan); got != tt.want {
+				t.Errorf("purgeNeeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPurgeSourcePaths(t *testing.T) {
+	var purged []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		purge := new(ImgixPurge)
+		if err := jsonapi.UnmarshalPayload(r.Body, purge); err != nil {
+			t.Error(err)
+			return
+		}
+		if r.URL.Path != "/api/v1/"+imgixPurgeEndpoint || purge.SourceID != "1" || !purge.SubImage {
+			t.Errorf("unexpected purge request %s %+v", r.URL.Path, purge)
+		}
+		purged = append(purged, purge.URL)
+		if strings.HasSuffix(purge.URL, "/broken.png") {
+			http.Error(w, "nope", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", jsonapi.MediaType)
+		purge.ID = "p"
+		_ = jsonapi.MarshalPayload(w, purge)
+	}))
+
+	source := &ImgixSource{ID: "1", Deployment: ImgixSourceDeployment{ImgixSubdomains: []string{"a", "b"}}}
+	purgeOnChange := &PurgeOnChangeModel{
+		Paths:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("/logo.png"), types.StringValue("broken.png")}),
+		SubImage: types.BoolValue(true),
+	}
+	diags := purgeSourcePaths(context.Background(), client, source, purgeOnChange)
+
+	want := []string{
+		"https://a.imgix.net/logo.png",
+		"https://a.imgix.net/broken.png",
+		"https://b.imgix.net/logo.png",
+		"https://b.imgix.net/broken.png",
+	}
+	if !reflect.DeepEqual(purged, want) {
+		t.Errorf("purged %v, want %v", purged, want)
+	}
+	if diags.HasError() || diags.WarningsCount() != 2 {
+		t.Errorf("diagnostics = %v, want a warning for each failed purge", diags)
+	}
+}
+
+func TestUpgradeSourceStateV0(t *testing.T) {
+	ctx := context.Background()
+	prior := &SourceModelV0{
+		ID:      types.StringValue("1"),
+		Name:    types.StringValue("profile"),
+		Enabled: types.BoolValue(true),
+		Deployment: &DeploymentModelV0{
+			Annotation:      types.StringValue("profile"),
+			Type:            types.StringValue("s3"),
+			S3Bucket:        types.StringValue("images"),
+			S3Prefix:        types.StringNull(),
+			S3AccessKey:     types.StringValue("AKIA"),
+			S3SecretKey:     types.StringValue("sk"),
+			ImgixSubdomains: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("profile"), types.StringValue("avatars")}),
+		},
+	}
+	upgrader := new(SourceResource).UpgradeState(ctx)[0]
+	req := resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema}}
+	if diags := req.State.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	schemaResp := new(resource.SchemaResponse)
+	new(SourceResource).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	state := new(SourceResourceModel)
+	if diags := resp.State.Get(ctx, state); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if state.DeploymentConfig != nil || state.Deployment == nil {
+		t.Fatalf("expected the deployment to stay in the block, got %+v", state)
+	}
+	expected := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("avatars"), types.StringValue("profile")})
+	if !state.Deployment.ImgixSubdomains.Equal(expected) {
+		t.Errorf("imgix_subdomains = %s, want %s", state.Deployment.ImgixSubdomains, expected)
+	}
+	if state.Deployment.S3SecretKey.ValueString() != "sk" || !verifySecretHash("sk", state.Deployment.S3SecretKeyHash.ValueString()) {
+		t.Errorf("s3_secret_key = %s, s3_secret_key_hash = %s, want the stored secret key and its hash", state.Deployment.S3SecretKey, state.Deployment.S3SecretKeyHash)
+	}
+}
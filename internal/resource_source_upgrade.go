@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// SourceModelV0 is the state model of the source resource before
+// imgix_subdomains became a set.
+type SourceModelV0 struct {
+	ID         types.String       `tfsdk:"id"`
+	Name       types.String       `tfsdk:"name"`
+	Deployment *DeploymentModelV0 `tfsdk:"deployment"`
+	Enabled    types.Bool         `tfsdk:"enabled"`
+}
+
+type DeploymentModelV0 struct {
+	Annotation      types.String `tfsdk:"annotation"`
+	Type            types.String `tfsdk:"type"`
+	S3Bucket        types.String `tfsdk:"s3_bucket"`
+	S3Prefix        types.String `tfsdk:"s3_prefix"`
+	S3AccessKey     types.String `tfsdk:"s3_access_key"`
+	S3SecretKey     types.String `tfsdk:"s3_secret_key"`
+	ImgixSubdomains types.List   `tfsdk:"imgix_subdomains"`
+}
+
+// sourceSchemaV0 is the schema of the source resource as released before versioning,
+// frozen so later changes to the current schema don't affect how old state is read.
+func sourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.StringAttribute{Computed: true},
+			"name":    schema.StringAttribute{Required: true},
+			"enabled": schema.BoolAttribute{Required: true},
+		},
+		Blocks: map[string]schema.Block{
+			"deployment": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"annotation":       schema.StringAttribute{Required: true},
+					"type":             schema.StringAttribute{Required: true},
+					"s3_bucket":        schema.StringAttribute{Optional: true},
+					"s3_prefix":        schema.StringAttribute{Optional: true},
+					"s3_access_key":    schema.StringAttribute{Optional: true, Sensitive: true},
+					"s3_secret_key":    schema.StringAttribute{Optional: true, Sensitive: true},
+					"imgix_subdomains": schema.ListAttribute{ElementType: types.StringType, Required: true},
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState satisfies the resource.ResourceWithUpgradeState interface for SourceResource.
+func (r *SourceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 is the only one released before the current one
+		0: {
+			PriorSchema:   sourceSchemaV0(),
+			StateUpgrader: upgradeSourceStateV0,
+		},
+	}
+}
+
+// upgradedDeployment wraps a prior deployment into the resource model. The secret key
+// stored by prior versions is hashed so it isn't sent again on the next apply.
+func upgradedDeployment(deployment *DeploymentModel) *DeploymentResourceModel {
+	if deployment == nil {
+		return nil
+	}
+	upgraded := &DeploymentResourceModel{
+		DeploymentModel:      *deployment,
+		S3SecretKeyWO:        types.StringNull(),
+		S3SecretKeyWOVersion: types.Int64Null(),
+		S3SecretKeyHash:      types.StringNull(),
+	}
+	secretKey := deployment.S3SecretKey.ValueString()
+	if secretKey != "" && secretKey != SECRET_KEY_PLACEHOLDER {
+		if hash, err := hashSecret(secretKey); err == nil {
+			upgraded.S3SecretKeyHash = types.StringValue(hash)
+		}
+	}
+	return upgraded
+}
+
+func upgradeSourceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	// Read the prior state into the old model
+	prior := new(SourceModelV0)
+	resp.Diagnostics.Append(req.State.Get(ctx, prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := &SourceResourceModel{
+		ID:      prior.ID,
+		Name:    prior.Name,
+		Enabled: prior.Enabled,
+	}
+
+	// Copy the deployment over, converting the subdomains from a list into a set
+	if prior.Deployment != nil {
+		subdomains := types.SetNull(types.StringType)
+		if !prior.Deployment.ImgixSubdomains.IsNull() {
+			var domains []string
+			resp.Diagnostics.Append(prior.Deployment.ImgixSubdomains.ElementsAs(ctx, &domains, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			s, diags := types.SetValueFrom(ctx, types.StringType, domains)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			subdomains = s
+		}
+		state.Deployment = upgradedDeployment(&DeploymentModel{
+			Annotation:      prior.Deployment.Annotation,
+			Type:            prior.Deployment.Type,
+			S3Bucket:        prior.Deployment.S3Bucket,
+			S3Prefix:        prior.Deployment.S3Prefix,
+			S3AccessKey:     prior.Deployment.S3AccessKey,
+			S3SecretKey:     prior.Deployment.S3SecretKey,
+			ImgixSubdomains: subdomains,
+		})
+	}
+
+	// Save the upgraded state
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
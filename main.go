@@ -3,12 +3,21 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
 	"github.com/Instawork/terraform-provider-imgixyz/internal"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
 func main() {
+	// `export` writes the existing sources as configuration instead of serving the provider
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := internal.Export(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	opts := providerserver.ServeOpts{
 		Address: "registry.terraform.io/Instawork/imgixyz",
 	}